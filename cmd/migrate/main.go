@@ -0,0 +1,113 @@
+// Entry point to create or update the database schema
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"time"
+
+	flag "github.com/spf13/pflag"
+
+	"github.com/thrasher-redhat/internal-tools/pkg/db"
+)
+
+// Flags
+var hostName = flag.StringP("hostname", "h", "postgresql", "the database hostname")
+var toVersion = flag.IntP("to", "t", 0, "the schema version to migrate to (defaults to the latest for up and the previous for down)")
+var force = flag.Bool("force", false, "allow reverting the base migrations, which drops every snapshot (needs an explicit --to)")
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage: %s [flags] [up|down|status]\n\n", os.Args[0])
+	fmt.Fprintln(os.Stderr, "  up      apply pending migrations (default)")
+	fmt.Fprintln(os.Stderr, "  down    revert the most recent migration")
+	fmt.Fprintf(os.Stderr, "  status  list the migrations and when they were applied\n\n")
+	flag.PrintDefaults()
+}
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+
+	command := "up"
+	if flag.NArg() > 1 {
+		usage()
+		os.Exit(2)
+	}
+	if flag.NArg() == 1 {
+		command = flag.Arg(0)
+	}
+
+	migrations, err := db.Migrations()
+	if err != nil {
+		log.Fatalf("Unable to load migrations: %v", err)
+	}
+
+	dbClient, err := db.NewClient(
+		os.Getenv("POSTGRESQL_USER"),
+		os.Getenv("POSTGRESQL_PASSWORD"),
+		os.Getenv("POSTGRESQL_DATABASE"),
+		"disable",
+		*hostName,
+	)
+	if err != nil {
+		log.Fatalf("Error creating database client: %v", err)
+	}
+	defer dbClient.Close()
+
+	applied, err := dbClient.AppliedMigrations()
+	if err != nil {
+		log.Fatalf("Unable to get the applied migrations: %v", err)
+	}
+
+	switch command {
+	case "status":
+		for _, m := range migrations {
+			if at, ok := applied[m.Version]; ok {
+				fmt.Printf("%v\tapplied %v\n", m, at.Format("2006-01-02 15:04:05"))
+			} else {
+				fmt.Printf("%v\tpending\n", m)
+			}
+		}
+		return
+	case "up":
+		if !flag.CommandLine.Changed("to") {
+			*toVersion, err = db.LatestVersion()
+			if err != nil {
+				log.Fatalf("Unable to load migrations: %v", err)
+			}
+		}
+	case "down":
+		if !flag.CommandLine.Changed("to") {
+			*toVersion = previousVersion(applied)
+		}
+	default:
+		usage()
+		os.Exit(2)
+	}
+
+	// The base migrations hold every snapshot, so they are only reverted when asked for explicitly
+	if db.RevertsBaseSchema(*toVersion, applied) && !(*force && flag.CommandLine.Changed("to")) {
+		log.Fatalf("Refusing to migrate to version %d as it would drop the bugs table and every snapshot, use --to %d --force to do it anyway", *toVersion, *toVersion)
+	}
+
+	err = dbClient.Migrate(*toVersion)
+	if err != nil {
+		log.Fatalf("Unable to migrate to version %d: %v", *toVersion, err)
+	}
+	log.Printf("Database schema is at version %d", *toVersion)
+}
+
+// previousVersion returns the version before the most recently applied migration
+func previousVersion(applied map[int]time.Time) int {
+	versions := make([]int, 0, len(applied))
+	for v := range applied {
+		versions = append(versions, v)
+	}
+	sort.Ints(versions)
+	if len(versions) < 2 {
+		return 0
+	}
+	return versions[len(versions)-2]
+}
@@ -57,7 +57,12 @@ func main() {
 	}
 	defer db.Close()
 
-	resolver, err := api.NewResolver(db, configs.Releases, configs.Blockers)
+	err = db.CheckSchema()
+	if err != nil {
+		log.Fatalf("Unable to serve: %v", err)
+	}
+
+	resolver, err := api.NewResolver(db, configs.Releases, configs.Blockers, configs.Trackers)
 	if err != nil {
 		log.Fatalf("Unable to create resolver: %v", err)
 	}
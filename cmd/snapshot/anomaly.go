@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/thrasher-redhat/internal-tools/pkg/bugzilla"
+)
+
+// anomalyError is returned when a query's bug counts changed more than the thresholds allow
+type anomalyError struct {
+	query     string
+	anomalies []string
+}
+
+func (e *anomalyError) Error() string {
+	return fmt.Sprintf("query %q changed too much since the previous snapshot: %s", e.query, strings.Join(e.anomalies, "; "))
+}
+
+// componentCounts returns the number of bugs in each primary component
+func componentCounts(bugs bugzilla.Bugs) map[string]int {
+	counts := make(map[string]int)
+	for _, b := range bugs.Bugs {
+		component := ""
+		if len(b.Component) > 0 {
+			component = b.Component[0]
+		}
+		counts[component]++
+	}
+	return counts
+}
+
+// findAnomalies compares the bug counts of each component (and the total) to the previous snapshot
+// Returns a description of every count that moved past its thresholds
+func findAnomalies(c AnomalyConfigs, previous, current map[string]int) []string {
+	var anomalies []string
+	previousTotal, currentTotal := 0, 0
+	for _, count := range previous {
+		previousTotal += count
+	}
+	for _, count := range current {
+		currentTotal += count
+	}
+	if a := checkCount(c.AnomalyThresholds, "total", previousTotal, currentTotal); a != "" {
+		anomalies = append(anomalies, a)
+	}
+
+	// A component that disappeared is a 100% drop, while a new one has nothing to compare to
+	components := make([]string, 0, len(previous))
+	for component := range previous {
+		components = append(components, component)
+	}
+	sort.Strings(components)
+	for _, component := range components {
+		name := fmt.Sprintf("component %q", component)
+		if a := checkCount(c.thresholds(component), name, previous[component], current[component]); a != "" {
+			anomalies = append(anomalies, a)
+		}
+	}
+	return anomalies
+}
+
+// checkCount describes how a single count moved past its thresholds, or returns an empty string if it didn't
+func checkCount(t AnomalyThresholds, name string, previous, current int) string {
+	if previous == 0 || previous < t.MinBugs {
+		return ""
+	}
+	change := float64(current-previous) / float64(previous) * 100
+	if t.MaxDrop > 0 && -change > t.MaxDrop {
+		return fmt.Sprintf("%s dropped %.0f%% from %d to %d bugs (max %v%%)", name, -change, previous, current, t.MaxDrop)
+	}
+	if t.MaxIncrease > 0 && change > t.MaxIncrease {
+		return fmt.Sprintf("%s increased %.0f%% from %d to %d bugs (max %v%%)", name, change, previous, current, t.MaxIncrease)
+	}
+	return ""
+}
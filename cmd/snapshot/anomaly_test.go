@@ -0,0 +1,92 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestFindAnomalies(t *testing.T) {
+	c := AnomalyConfigs{
+		AnomalyThresholds: AnomalyThresholds{MaxDrop: 50, MaxIncrease: 100, MinBugs: 10},
+		Components: map[string]AnomalyThresholds{
+			"docs": {MaxDrop: 90, MinBugs: 10},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		previous map[string]int
+		current  map[string]int
+		expected int
+	}{
+		{
+			name:     "no previous snapshot",
+			previous: map[string]int{},
+			current:  map[string]int{"a": 100},
+		},
+		{
+			name:     "small changes",
+			previous: map[string]int{"a": 100, "b": 50},
+			current:  map[string]int{"a": 90, "b": 60},
+		},
+		{
+			name:     "partial result",
+			previous: map[string]int{"a": 800, "b": 100},
+			current:  map[string]int{"a": 10, "b": 2},
+			expected: 3,
+		},
+		{
+			name:     "component disappeared",
+			previous: map[string]int{"a": 100, "b": 20},
+			current:  map[string]int{"a": 100},
+			expected: 1,
+		},
+		{
+			name:     "too few bugs to compare",
+			previous: map[string]int{"a": 100, "b": 5},
+			current:  map[string]int{"a": 100, "b": 50},
+		},
+		{
+			name:     "increase",
+			previous: map[string]int{"a": 100},
+			current:  map[string]int{"a": 250},
+			expected: 2,
+		},
+		{
+			name:     "new component",
+			previous: map[string]int{"a": 100},
+			current:  map[string]int{"a": 100, "c": 50},
+		},
+		{
+			name:     "component override",
+			previous: map[string]int{"a": 100, "docs": 20},
+			current:  map[string]int{"a": 100, "docs": 5},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			anomalies := findAnomalies(c, tt.previous, tt.current)
+			if len(anomalies) != tt.expected {
+				t.Errorf("expected %d anomalies, got %d: %v", tt.expected, len(anomalies), anomalies)
+			}
+		})
+	}
+}
+
+func TestAnomalyConfigsValidate(t *testing.T) {
+	valid := AnomalyConfigs{AnomalyThresholds: AnomalyThresholds{MaxDrop: 50}, Action: anomalyQuarantine}
+	if err := valid.validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	invalid := []AnomalyConfigs{
+		{Action: "ignore"},
+		{AnomalyThresholds: AnomalyThresholds{MaxDrop: 150}},
+		{Components: map[string]AnomalyThresholds{"a": {MaxIncrease: -1}}},
+	}
+	for _, c := range invalid {
+		if err := c.validate(); err == nil {
+			t.Errorf("expected an error for %+v", c)
+		}
+	}
+}
@@ -2,13 +2,16 @@
 
 Snapshot will query bugzilla (and trello) and then will attempt to store the
 data into a postgresql database.  The data will be inserted with a datestamp
-that pairs with the id to make a primary key.  Snapshot will remove any
-previous data from today and insert the new data in a single transaction.  This
-means there will always be a single snapshot per day.
+and the name of the query that found it, which pair with the id to make a
+primary key.  For each query, snapshot will remove any previous data from today
+and insert the new data in a single transaction.  This means there will always
+be a single daily snapshot per query, though every run can optionally be kept
+for a few days as well (see Hourly below).
 
 This is intended to be run as a cron job in OpenShift - however it can also be
 run locally. It is assumed that a postgresql database exists where the relevant
-tables have been created.
+tables have been created with the migrate command.  The snapshot will not start
+if the database is missing any migrations.
 
 *******************************************************************************
 
@@ -22,9 +25,10 @@ pod.
 It is recommended to snapshot once per hour if you need up-to-date data for
 today.  We want to snapshot the state at the same time every day to get
 accurate data that can be easily compared.  The default cron job will run once
-per hour from 0600 - 2000.  Only the latest snapshot per day is kept, so our
-database will have snapshots from everyday at 2000 to provide a history to
-analyze.
+per hour from 0600 - 2000.  Only the latest snapshot per day is kept as the
+daily snapshot, so our database will have snapshots from everyday at 2000 to
+provide a history to analyze.  With Hourly enabled, the earlier runs of the
+last few days are kept as well.
 
 schedule: '0 6-20 * * *'
 
@@ -40,6 +44,82 @@ export POSTGRESQL_USER="myusername"
 export POSTGRESQL_PASSWORD="mypassword"
 export POSTGRESQL_DATABASE="mydatabasename"
 
-go run cmd/snapshot/main.go cmd/snapshot/options.go -c /path/to/snapshot_cfg.yaml -h localhost
+go run cmd/snapshot/*.go -c /path/to/snapshot_cfg.yaml -h localhost
+
+*******************************************************************************
+
+Each query is snapshotted even if an earlier one fails.  If a bugzilla query
+fails, snapshot exits with a status describing why:
+
+1 - any other error
+2 - bugzilla rejected the credentials
+3 - the saved search does not exist or is not shared
+4 - the user does not have permission to view the results
+5 - bugzilla reported an internal fault
+6 - bugzilla could not be reached or returned a non-200 response
+7 - the bug counts changed more than the anomaly thresholds allow
+
+*******************************************************************************
+
+Every run of a query is recorded in the snapshot_runs table with how long the
+tracker took, how many bugs were stored, and why it failed if it did.
+
+A query whose bug counts moved too far from its previous day's snapshot is not
+stored, so that a partial result from bugzilla doesn't look like hundreds of
+closed bugs.  The thresholds are set in the Anomalies section of the config, as
+a maximum percentage drop and increase for the query's total and for each
+component.  With the quarantine action, the new bugs are stored aside in the
+quarantined_bugs table for review.  If the change is real, run snapshot again
+with --allow-anomalies to store it anyway.
+
+*******************************************************************************
+
+Each run replaces the day's bugs, so only the last run of the day is kept.  To
+keep every run for a while, enable the Hourly section of the config:
+
+	Hourly:
+	  enabled: true
+	  retention_days: 7
+
+Each run's bugs are then also stored in the bug_snapshots_hourly table, in the
+same transaction as the daily snapshot, under the time the run started.  The
+bug queries of the api accept an RFC3339 time as their date and use the last
+hourly snapshot taken at or before that time, ex) to watch the bugs on release
+day.
+
+After the queries, every run compacts the hourly snapshots from before
+retention_days ago (default 7).  The last hourly snapshot of each of those days
+is kept as the daily snapshot if the day doesn't have one, and the rest are
+deleted.  Hourly snapshots are off by default, as they store every bug once per
+run.
+
+*******************************************************************************
+
+After the queries, snapshot fetches the change history of every bug whose
+last_change_time is later than when its history was last fetched and stores
+it in the bug_changes table.  A failed fetch is tried again on the next run.
+This needs last_change_time in the configured fields and can be turned off
+with skip_history.
+
+Each query also fetches the comments on its bugs to store the comment count,
+latest comment time, and latest commenter (but not the text) with the bugs.
+This can be turned off with skip_comments.
+
+Jira issues are snapshotted from named JQL queries the same way.  They are
+converted into bugs (components, fix versions as target releases, labels as
+keywords) and stored in the bugs table with a source of "jira", while bugzilla
+bugs have a source of "bugzilla".  Query names must be unique across sources.
+A failed jira query uses the same exit statuses, based on jira's HTTP status.
+There is no history or comment data for jira issues.
+
+The open issues and pull requests of each configured github repo are stored
+in the github_issues table.  The github issues and pull requests that the
+bugzilla bugs link to (through the configured github external trackers) are
+stored as well, so the api can show whether a bug's upstream pull request has
+merged.  Linked issues that no longer exist are skipped.  A failed repo uses
+the same exit statuses, based on github's HTTP status.
+
+Each configured trello board is snapshotted into the cards table the same way,
+with the cards stored under the board's name.  A failed board exits with 1.
 */
 package main
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"sort"
+
+	"github.com/thrasher-redhat/internal-tools/pkg/bugzilla"
+	"github.com/thrasher-redhat/internal-tools/pkg/db"
+	"github.com/thrasher-redhat/internal-tools/pkg/github"
+)
+
+// newGitHubClient creates a github client for the configured api
+func newGitHubClient(c GitHubConfigs) (github.Client, error) {
+	token := c.Token
+	// Allow the token to come from a secret instead of the configmap
+	if token == "" {
+		token = os.Getenv("GITHUB_TOKEN")
+	}
+
+	httpClient, err := bugzilla.NewHTTPClient(bugzilla.HTTPOptions{
+		Timeout:  c.Timeout,
+		Proxy:    c.Proxy,
+		CABundle: c.CABundle,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return github.NewClient(c.URL, github.Options{
+		Token:      token,
+		HTTPClient: httpClient,
+	}), nil
+}
+
+// linkedRefs returns the github issues and pull requests that the bugs link to in the given trackers
+func linkedRefs(bugs []bugzilla.Bug, trackers []int) []github.Ref {
+	seen := make(map[github.Ref]bool)
+	var refs []github.Ref
+	for _, b := range bugs {
+		for _, ext := range b.Externals {
+			if !ext.InTrackers(trackers) {
+				continue
+			}
+			ref, ok := github.ParseRef(ext.ExternalID)
+			if !ok {
+				log.Printf("Bug %d links to %q, which is not a github issue or pull request", b.ID, ext.ExternalID)
+				continue
+			}
+			if !seen[ref] {
+				seen[ref] = true
+				refs = append(refs, ref)
+			}
+		}
+	}
+	sort.Slice(refs, func(i, j int) bool {
+		if refs[i].Repo != refs[j].Repo {
+			return refs[i].Repo < refs[j].Repo
+		}
+		return refs[i].Number < refs[j].Number
+	})
+	return refs
+}
+
+// snapshotGitHub stores the issues and pull requests of each repository, then the state of the linked ones
+// Linked issues that were already stored with their repository are not fetched again.
+// A failed repository or linked issue doesn't stop the others, but the first error is returned.
+func snapshotGitHub(client github.Client, dbClient db.Client, c GitHubConfigs, repos []GitHubRepoConfigs, linked []github.Ref) error {
+	ctx, cancel := queryContext(c.QueryTimeout)
+	defer cancel()
+
+	var firstErr error
+	stored := make(map[github.Ref]bool)
+	for _, r := range repos {
+		issues, err := client.Issues(ctx, r.Name, github.IssueFilter{Labels: r.Labels, Milestone: r.Milestone})
+		if err == nil {
+			log.Printf("Repo %q has %d open issues and pull requests\n", r.Name, len(issues))
+			err = dbClient.SnapshotGitHub(r.Name, issues)
+		}
+		if err != nil {
+			log.Printf("Error snapshotting github repo %q: %v", r.Name, err)
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		for _, issue := range issues {
+			stored[github.Ref{Repo: issue.Repo, Number: issue.Number}] = true
+		}
+	}
+
+	var issues []github.Issue
+	for _, ref := range linked {
+		if stored[ref] {
+			continue
+		}
+		issue, err := client.Issue(ctx, ref)
+		if ghErr, ok := err.(*github.Error); ok && ghErr.StatusCode == http.StatusNotFound {
+			// Deleted and private issues would fail every snapshot, so only note them
+			log.Printf("Linked github issue %v was not found", ref)
+			continue
+		}
+		if err != nil {
+			log.Printf("Error fetching linked github issue %v: %v", ref, err)
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		issues = append(issues, issue)
+	}
+	log.Printf("Fetched %d github issues and pull requests linked from bugzilla\n", len(issues))
+
+	if len(issues) > 0 {
+		err := dbClient.StoreGitHubIssues(issues)
+		if err != nil {
+			return fmt.Errorf("error storing linked github issues to database: %v", err)
+		}
+	}
+	return firstErr
+}
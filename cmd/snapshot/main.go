@@ -1,17 +1,311 @@
 package main
 
 import (
+	"context"
+	"fmt"
 	"log"
+	"net/http"
 	"os"
+	"sort"
+	"time"
 
 	flag "github.com/spf13/pflag"
 
 	"github.com/thrasher-redhat/internal-tools/pkg/bugzilla"
 	"github.com/thrasher-redhat/internal-tools/pkg/db"
+	"github.com/thrasher-redhat/internal-tools/pkg/github"
+	"github.com/thrasher-redhat/internal-tools/pkg/jira"
+	"github.com/thrasher-redhat/internal-tools/pkg/trello"
 )
 
+// Exit statuses for the snapshot process
+// These let alerting tell a credentials problem from a bugzilla outage
+const (
+	exitError      = 1
+	exitAuth       = 2
+	exitSavedQuery = 3
+	exitPermission = 4
+	exitServer     = 5
+	exitTransport  = 6
+	exitAnomaly    = 7
+)
+
+// exitStatus picks the exit status for an error returned by the bugzilla, jira, or github client
+func exitStatus(err error) int {
+	if _, ok := err.(*anomalyError); ok {
+		return exitAnomaly
+	}
+	if jiraErr, ok := err.(*jira.Error); ok {
+		return httpExitStatus(jiraErr.StatusCode)
+	}
+	if ghErr, ok := err.(*github.Error); ok {
+		return httpExitStatus(ghErr.StatusCode)
+	}
+	bzErr, ok := err.(*bugzilla.Error)
+	if !ok {
+		return exitError
+	}
+	switch bzErr.Kind {
+	case bugzilla.ErrorAuth:
+		return exitAuth
+	case bugzilla.ErrorSavedSearch:
+		return exitSavedQuery
+	case bugzilla.ErrorPermission:
+		return exitPermission
+	case bugzilla.ErrorServer:
+		return exitServer
+	case bugzilla.ErrorTransport:
+		return exitTransport
+	default:
+		return exitError
+	}
+}
+
+// httpExitStatus picks the exit status for a jira or github error from its HTTP status
+// A status of zero means no response was received
+func httpExitStatus(statusCode int) int {
+	switch {
+	case statusCode == 0:
+		return exitTransport
+	case statusCode == http.StatusUnauthorized:
+		return exitAuth
+	case statusCode == http.StatusForbidden:
+		return exitPermission
+	case statusCode >= http.StatusInternalServerError:
+		return exitServer
+	default:
+		return exitError
+	}
+}
+
+// newBugzillaClient creates a bugzilla client for the configured API
+func newBugzillaClient(c BugzillaConfigs) (bugzilla.ContextClient, error) {
+	creds := bugzilla.Credentials{
+		Method:   bugzilla.AuthMethod(c.Auth),
+		APIKey:   c.APIKey,
+		Header:   c.AuthHeader,
+		Username: c.User,
+		Password: c.Pass,
+	}
+	// Allow the api key to come from a secret instead of the configmap
+	if creds.APIKey == "" {
+		creds.APIKey = os.Getenv("BUGZILLA_API_KEY")
+	}
+
+	httpClient, err := bugzilla.NewHTTPClient(bugzilla.HTTPOptions{
+		Timeout:  c.Timeout,
+		Proxy:    c.Proxy,
+		CABundle: c.CABundle,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	options := bugzilla.Options{
+		PageSize:   c.PageSize,
+		HTTPClient: httpClient,
+		Retries:    c.Retries,
+		RetryWait:  c.RetryWait,
+	}
+
+	switch c.API {
+	case "", "jsonrpc":
+		return bugzilla.NewClient(creds, c.URL, options), nil
+	case "rest":
+		return bugzilla.NewRESTClient(creds, c.URL, options), nil
+	default:
+		return nil, fmt.Errorf("unknown bugzilla api %q", c.API)
+	}
+}
+
+// executeQuery runs the query's search criteria, or the saved search if there are no criteria
+func executeQuery(ctx context.Context, client bugzilla.ContextClient, q QueryConfigs, fields []string) (bugzilla.Bugs, error) {
+	if q.Criteria != nil {
+		if err := q.Criteria.Validate(); err != nil {
+			return bugzilla.Bugs{}, err
+		}
+		return client.SearchContext(ctx, *q.Criteria, fields)
+	}
+	return client.ExecuteQueryContext(ctx, q.Search, q.ShareID, fields)
+}
+
+// queryContext returns a context limited by the query timeout, if any
+// Don't let a hung tracker keep the pod running
+func queryContext(timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout > 0 {
+		return context.WithTimeout(context.Background(), timeout)
+	}
+	return context.WithCancel(context.Background())
+}
+
+// snapshotQuery runs a single named query of a source and stores the bugs under the query's name
+// Every run is recorded in the database, including failed runs
+func snapshotQuery(s source, dbClient db.Client, name string, configs Configs) (bugzilla.Bugs, error) {
+	run := db.SnapshotRun{
+		QueryName: name,
+		Source:    s.name(),
+		StartedAt: time.Now(),
+		Hourly:    configs.Hourly.Enabled,
+	}
+	bugs, err := s.query(name)
+	run.FetchDuration = time.Since(run.StartedAt)
+	if err != nil {
+		return bugzilla.Bugs{}, failedRun(dbClient, run, err)
+	}
+	log.Printf("Query %q found %d %s bugs in %v\n", name, len(bugs.Bugs), s.name(), run.FetchDuration)
+
+	// Don't overwrite old bugs if we have no new bugs
+	if len(bugs.Bugs) == 0 {
+		return bugzilla.Bugs{}, failedRun(dbClient, run, fmt.Errorf("query %q found no bugs, ensure query is correct", name))
+	}
+
+	for i := range bugs.Bugs {
+		bugs.Bugs[i].Source = s.name()
+	}
+
+	// Don't replace good bugs with a partial result either, unless we were told the change is real
+	previous, err := dbClient.GetPreviousComponentCounts(name)
+	if err != nil {
+		return bugzilla.Bugs{}, failedRun(dbClient, run, fmt.Errorf("error getting the previous snapshot of query %q: %v", name, err))
+	}
+	found := findAnomalies(configs.Anomalies, previous, componentCounts(bugs))
+	if len(found) > 0 {
+		err = &anomalyError{query: name, anomalies: found}
+		if !*allowAnomalies {
+			return bugzilla.Bugs{}, rejectedRun(dbClient, run, bugs, configs.Anomalies, err)
+		}
+		log.Printf("Storing the snapshot anyway: %v", err)
+	}
+
+	err = dbClient.SnapshotBugzilla(name, bugs, run)
+	if err != nil {
+		return bugzilla.Bugs{}, failedRun(dbClient, run, fmt.Errorf("error storing snapshot to database: %v", err))
+	}
+	return bugs, nil
+}
+
+// failedRun records a failed run of a snapshot query and returns the reason it failed
+func failedRun(dbClient db.Client, run db.SnapshotRun, err error) error {
+	run.FinishedAt = time.Now()
+	run.Error = err.Error()
+	storeErr := dbClient.StoreSnapshotRun(run)
+	if storeErr != nil {
+		log.Printf("Unable to record the failed run of query %q: %v", run.QueryName, storeErr)
+	}
+	return err
+}
+
+// rejectedRun records a run whose bugs weren't stored because their counts looked wrong and returns the reason
+// The bugs are stored aside if the anomaly action is to quarantine them
+func rejectedRun(dbClient db.Client, run db.SnapshotRun, bugs bugzilla.Bugs, anomalies AnomalyConfigs, err error) error {
+	if !anomalies.quarantine() {
+		return failedRun(dbClient, run, err)
+	}
+	run.Error = err.Error()
+	storeErr := dbClient.QuarantineBugzilla(run.QueryName, bugs, run)
+	if storeErr != nil {
+		log.Printf("Unable to quarantine the bugs of query %q: %v", run.QueryName, storeErr)
+		return failedRun(dbClient, run, err)
+	}
+	return err
+}
+
+// addComments fills in the comment count and latest comment of each bug
+func addComments(ctx context.Context, client bugzilla.ContextClient, bugs bugzilla.Bugs) error {
+	ids := make([]int, len(bugs.Bugs))
+	for i, b := range bugs.Bugs {
+		ids[i] = b.ID
+	}
+
+	comments, err := client.CommentsContext(ctx, ids, bugzilla.CommentOptions{})
+	if err != nil {
+		return err
+	}
+
+	summaries := make(map[int]bugzilla.CommentSummary, len(comments))
+	for _, c := range comments {
+		summaries[c.ID] = c.Summary()
+	}
+	for i := range bugs.Bugs {
+		bugs.Bugs[i].Comments = summaries[bugs.Bugs[i].ID]
+	}
+	return nil
+}
+
+// changedBugs returns the ids of bugs that changed since their history was last fetched
+// Bugs without a last change time (the field wasn't requested) are skipped, while bugs without any stored changes are always included
+func changedBugs(dbClient db.Client, changeTimes map[int]time.Time) ([]int, error) {
+	ids := make([]int, 0, len(changeTimes))
+	for id := range changeTimes {
+		ids = append(ids, id)
+	}
+
+	fetched, err := dbClient.GetHistoryFetchTimes(ids)
+	if err != nil {
+		return nil, fmt.Errorf("error getting history fetch times: %v", err)
+	}
+
+	var changed []int
+	for _, id := range ids {
+		last, ok := fetched[id]
+		if !ok || changeTimes[id].After(last) {
+			changed = append(changed, id)
+		}
+	}
+	sort.Ints(changed)
+	return changed, nil
+}
+
+// snapshotHistory fetches and stores the change history of every bug that changed since its history was last fetched
+func snapshotHistory(client bugzilla.ContextClient, dbClient db.Client, c BugzillaConfigs, changeTimes map[int]time.Time) error {
+	ids, err := changedBugs(dbClient, changeTimes)
+	if err != nil {
+		return err
+	}
+	log.Printf("Fetching history for %d changed bugs\n", len(ids))
+	if len(ids) == 0 {
+		return nil
+	}
+
+	ctx, cancel := queryContext(c.QueryTimeout)
+	defer cancel()
+
+	histories, err := client.HistoryContext(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	err = dbClient.StoreHistory(histories)
+	if err != nil {
+		return fmt.Errorf("error storing history to database: %v", err)
+	}
+	return nil
+}
+
+// snapshotBoard stores the cards on a single board under the board's name
+func snapshotBoard(client trello.Client, dbClient db.Client, b BoardConfigs) error {
+	// A board missing some of its lists would skew the cards per list, so don't store it
+	cards, err := trello.FilterCards(b.ID, b.Prefix, client)
+	if err != nil {
+		return err
+	}
+	log.Printf("Board %q has %d cards\n", b.Name, len(cards))
+
+	// Don't overwrite old cards if we have no new cards
+	if len(cards) == 0 {
+		return fmt.Errorf("board %q has no cards, ensure the board id and prefix are correct", b.Name)
+	}
+
+	err = dbClient.SnapshotTrello(b.Name, cards)
+	if err != nil {
+		return fmt.Errorf("error storing cards to database: %v", err)
+	}
+	return nil
+}
+
 var configFile = flag.StringP("config", "c", "/etc/internal-tools/snapshot_cfg.yaml", "the configurations file")
 var hostName = flag.StringP("hostname", "h", "postgresql", "the database hostname")
+var allowAnomalies = flag.Bool("allow-anomalies", false, "store the snapshots even if their bug counts changed more than the anomaly thresholds allow")
 
 func main() {
 	// Grab the configuration values
@@ -21,28 +315,30 @@ func main() {
 		log.Fatalf("Unable to get configs: %v", err)
 	}
 
-	// Create a custom bugzilla client and fetch list of bugs
-	bugClient := bugzilla.NewClient(
-		configs.Sources.Bugzilla.User,
-		configs.Sources.Bugzilla.Pass,
-		configs.Sources.Bugzilla.URL,
-	)
-	bugs, err := bugClient.ExecuteQuery(
-		configs.Sources.Bugzilla.Search,
-		configs.Sources.Bugzilla.ShareID,
-		configs.Sources.Bugzilla.Fields,
-	)
+	err = configs.Anomalies.validate()
 	if err != nil {
-		log.Fatalf("Error executing query: %v", err)
+		log.Fatalf("Invalid anomaly thresholds: %v", err)
+	}
+	boards, err := configs.Sources.Trello.boards()
+	if err != nil {
+		log.Fatalf("Invalid trello boards: %v", err)
+	}
+	repos, err := configs.Sources.GitHub.repos()
+	if err != nil {
+		log.Fatalf("Invalid github repos: %v", err)
 	}
-	log.Printf("Query found %d bugs\n", len(bugs.Bugs))
 
-	// Don't overwrite old bugs if we have no new bugs
-	if len(bugs.Bugs) == 0 {
-		log.Fatalf("Query found no bugs. Ensure query is correct.\n")
+	// Create a custom bugzilla client
+	bugClient, err := newBugzillaClient(configs.Sources.Bugzilla)
+	if err != nil {
+		log.Fatalf("Unable to create bugzilla client: %v", err)
+	}
+	sources, err := newSources(configs.Sources, bugClient)
+	if err != nil {
+		log.Fatalf("Unable to create sources: %v", err)
 	}
 
-	// Create database client and store the bugs
+	// Create database client to store the bugs
 	dbClient, err := db.NewClient(
 		os.Getenv("POSTGRESQL_USER"),
 		os.Getenv("POSTGRESQL_PASSWORD"),
@@ -54,9 +350,86 @@ func main() {
 		log.Fatalf("Error creating database client: %v", err)
 	}
 	defer dbClient.Close()
-	err = dbClient.SnapshotBugzilla(bugs)
+
+	// Snapshots written against an old schema would fail part way through
+	err = dbClient.CheckSchema()
 	if err != nil {
-		log.Fatalf("Error storing snapshot to database: %v", err)
+		log.Fatalf("Unable to snapshot: %v", err)
+	}
+
+	// Snapshot each query on its own so that one bad query doesn't stop the others
+	// A bug found by more than one query only needs its history fetched once
+	// Only bugzilla has a history to fetch, or links to github
+	status := 0
+	changeTimes := make(map[int]time.Time)
+	var bugzillaBugs []bugzilla.Bug
+	for _, s := range sources {
+		for _, name := range s.queryNames() {
+			bugs, err := snapshotQuery(s, dbClient, name, configs)
+			if err != nil {
+				log.Printf("Error snapshotting %s query %q: %v", s.name(), name, err)
+				status = exitStatus(err)
+				continue
+			}
+			if s.name() != bugzilla.Source {
+				continue
+			}
+			bugzillaBugs = append(bugzillaBugs, bugs.Bugs...)
+			for _, b := range bugs.Bugs {
+				if !b.LastChangeTime.IsZero() {
+					changeTimes[b.ID] = b.LastChangeTime
+				}
+			}
+		}
+	}
+
+	// Hourly snapshots older than the retention are compacted down to the daily snapshot
+	if configs.Hourly.Enabled {
+		before := time.Now().AddDate(0, 0, -configs.Hourly.retention())
+		removed, err := dbClient.CompactHourlySnapshots(before)
+		if err != nil {
+			log.Printf("Error compacting hourly snapshots: %v", err)
+			status = exitError
+		} else {
+			log.Printf("Removed %d hourly snapshot bugs from before %v\n", removed, before.Format("2006-01-02"))
+		}
+	}
+
+	if !configs.Sources.Bugzilla.SkipHistory {
+		err = snapshotHistory(bugClient, dbClient, configs.Sources.Bugzilla, changeTimes)
+		if err != nil {
+			log.Printf("Error snapshotting bug history: %v", err)
+			status = exitStatus(err)
+		}
+	}
+	// Snapshot the github repos and the issues that bugzilla links to
+	if configs.Sources.GitHub.enabled() {
+		ghClient, err := newGitHubClient(configs.Sources.GitHub)
+		if err != nil {
+			log.Fatalf("Unable to create github client: %v", err)
+		}
+		err = snapshotGitHub(ghClient, dbClient, configs.Sources.GitHub, repos, linkedRefs(bugzillaBugs, configs.Sources.GitHub.Trackers))
+		if err != nil {
+			log.Printf("Error snapshotting github: %v", err)
+			status = exitStatus(err)
+		}
+	}
+
+	// Snapshot each board on its own as well
+	if len(boards) > 0 {
+		trelloClient := trello.ClientForConfig(&configs.Sources.Trello.TrelloCredentials)
+		for _, b := range boards {
+			err = snapshotBoard(trelloClient, dbClient, b)
+			if err != nil {
+				log.Printf("Error snapshotting board %q: %v", b.Name, err)
+				status = exitError
+			}
+		}
+	}
+
+	if status != 0 {
+		dbClient.Close()
+		os.Exit(status)
 	}
 
 	log.Println("Snapshot done.")
@@ -4,29 +4,309 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"strings"
+	"time"
 
 	yaml "gopkg.in/yaml.v2"
+
+	"github.com/thrasher-redhat/internal-tools/pkg/bugzilla"
+	"github.com/thrasher-redhat/internal-tools/pkg/trello"
 )
 
 // BugzillaConfigs stores the query and login information needed for the Bugzilla API
 type BugzillaConfigs struct {
-	Search  string   `yaml:"search"`
-	ShareID string   `yaml:"sharer"`
-	Fields  []string `yaml:"fields"`
-	URL     string   `yaml:"url"`
-	User    string   `yaml:"user"`
-	Pass    string   `yaml:"pass"`
+	// API is either "jsonrpc" (default) or "rest"
+	API string `yaml:"api"`
+	// Queries are the named queries to snapshot
+	// If there are none, the criteria or saved search below is used as the "default" query
+	Queries []QueryConfigs `yaml:"queries"`
+	// Criteria describes the search directly.  If not given, the saved search is used.
+	Criteria *bugzilla.Criteria `yaml:"criteria"`
+	Search   string             `yaml:"search"`
+	ShareID  string             `yaml:"sharer"`
+	Fields   []string           `yaml:"fields"`
+	URL      string             `yaml:"url"`
+	// Auth is "api_key", "token", or "password"
+	// Defaults to api_key if an api key is given, otherwise password
+	Auth string `yaml:"auth"`
+	// APIKey can also be given with the BUGZILLA_API_KEY environment variable
+	APIKey string `yaml:"api_key"`
+	// AuthHeader sends the api key or token as a header instead of a parameter
+	AuthHeader bool   `yaml:"auth_header"`
+	User       string `yaml:"user"`
+	Pass       string `yaml:"pass"`
+	// PageSize is the number of bugs to request at a time
+	PageSize int `yaml:"page_size"`
+	// Timeout is the limit for a single request and QueryTimeout is the limit for the whole query
+	Timeout      time.Duration `yaml:"timeout"`
+	QueryTimeout time.Duration `yaml:"query_timeout"`
+	// Proxy and CABundle configure how we connect to bugzilla
+	Proxy    string `yaml:"proxy"`
+	CABundle string `yaml:"ca_bundle"`
+	// Retries is how many times a request is retried after a 5xx or transport error
+	// RetryWait is the wait before the first retry, which doubles after each retry
+	Retries   int           `yaml:"retries"`
+	RetryWait time.Duration `yaml:"retry_wait"`
+	// SkipHistory turns off fetching the change history of bugs that changed since the last snapshot
+	SkipHistory bool `yaml:"skip_history"`
+	// SkipComments turns off fetching the comment count and latest comment of each bug
+	SkipComments bool `yaml:"skip_comments"`
+}
+
+// QueryConfigs stores a single named query
+// Either Criteria or a saved search (Search and ShareID) should be given
+type QueryConfigs struct {
+	Name     string             `yaml:"name"`
+	Criteria *bugzilla.Criteria `yaml:"criteria"`
+	Search   string             `yaml:"search"`
+	ShareID  string             `yaml:"sharer"`
+}
+
+// defaultQueryName is the name used when the config doesn't define a list of queries
+const defaultQueryName = "default"
+
+// queries returns the list of named queries to snapshot
+func (c BugzillaConfigs) queries() ([]QueryConfigs, error) {
+	if len(c.Queries) == 0 {
+		return []QueryConfigs{{
+			Name:     defaultQueryName,
+			Criteria: c.Criteria,
+			Search:   c.Search,
+			ShareID:  c.ShareID,
+		}}, nil
+	}
+
+	names := make(map[string]bool)
+	for _, q := range c.Queries {
+		if q.Name == "" {
+			return nil, fmt.Errorf("bugzilla queries must have a name")
+		}
+		if names[q.Name] {
+			return nil, fmt.Errorf("duplicate bugzilla query name %q", q.Name)
+		}
+		names[q.Name] = true
+	}
+	return c.Queries, nil
+}
+
+// JiraConfigs stores the queries and login information needed for the Jira API
+type JiraConfigs struct {
+	// URL is the base of the jira instance, ex) https://jira.example.com
+	URL string `yaml:"url"`
+	// Queries are the named JQL queries to snapshot.  If there are none, jira is skipped.
+	Queries []JiraQueryConfigs `yaml:"queries"`
+	// Fields are extra issue fields to store with the bugs, ex) customfield_10002
+	Fields []string `yaml:"fields"`
+	// User and Token log in with basic auth (jira cloud).  A token without a user is sent as a bearer token (jira server).
+	User string `yaml:"user"`
+	Pass string `yaml:"pass"`
+	// Token can also be given with the JIRA_API_TOKEN environment variable
+	Token string `yaml:"token"`
+	// PageSize is the number of issues to request at a time
+	PageSize int `yaml:"page_size"`
+	// Timeout is the limit for a single request and QueryTimeout is the limit for the whole query
+	Timeout      time.Duration `yaml:"timeout"`
+	QueryTimeout time.Duration `yaml:"query_timeout"`
+	// Proxy and CABundle configure how we connect to jira
+	Proxy    string `yaml:"proxy"`
+	CABundle string `yaml:"ca_bundle"`
+}
+
+// JiraQueryConfigs stores a single named JQL query
+type JiraQueryConfigs struct {
+	Name string `yaml:"name"`
+	JQL  string `yaml:"jql"`
+}
+
+// queries validates that the jira queries have a query and unique names
+func (c JiraConfigs) queries() ([]JiraQueryConfigs, error) {
+	names := make(map[string]bool)
+	for _, q := range c.Queries {
+		if q.Name == "" || q.JQL == "" {
+			return nil, fmt.Errorf("jira queries must have a name and a jql query")
+		}
+		if names[q.Name] {
+			return nil, fmt.Errorf("duplicate jira query name %q", q.Name)
+		}
+		names[q.Name] = true
+	}
+	if len(c.Queries) > 0 && c.URL == "" {
+		return nil, fmt.Errorf("jira queries need a jira url")
+	}
+	return c.Queries, nil
+}
+
+// GitHubConfigs stores the repositories and login information needed for the GitHub API
+type GitHubConfigs struct {
+	// URL is the base of the api.  Defaults to https://api.github.com.
+	URL string `yaml:"url"`
+	// Token can also be given with the GITHUB_TOKEN environment variable
+	Token string `yaml:"token"`
+	// Repos are the repositories whose open issues and pull requests are snapshotted
+	Repos []GitHubRepoConfigs `yaml:"repos"`
+	// Trackers are the bugzilla external tracker ids that point at github
+	// The issues and pull requests that bugzilla bugs link to in these trackers are snapshotted as well.
+	// With no trackers, linked issues are not fetched.
+	Trackers []int `yaml:"trackers"`
+	// Timeout is the limit for a single request and QueryTimeout is the limit for the whole snapshot
+	Timeout      time.Duration `yaml:"timeout"`
+	QueryTimeout time.Duration `yaml:"query_timeout"`
+	// Proxy and CABundle configure how we connect to github
+	Proxy    string `yaml:"proxy"`
+	CABundle string `yaml:"ca_bundle"`
+}
+
+// GitHubRepoConfigs stores a single repository to snapshot
+type GitHubRepoConfigs struct {
+	// Name is the owner and name of the repository, ex) openshift/origin
+	Name string `yaml:"name"`
+	// Labels only keeps issues with all of the given labels
+	Labels []string `yaml:"labels"`
+	// Milestone only keeps issues in the milestone with the given title
+	Milestone string `yaml:"milestone"`
+}
+
+// repos validates that the repositories are named owner/name and are unique
+func (c GitHubConfigs) repos() ([]GitHubRepoConfigs, error) {
+	names := make(map[string]bool)
+	for _, r := range c.Repos {
+		if strings.Count(r.Name, "/") != 1 || strings.HasPrefix(r.Name, "/") || strings.HasSuffix(r.Name, "/") {
+			return nil, fmt.Errorf("github repo %q must be named owner/name", r.Name)
+		}
+		if names[r.Name] {
+			return nil, fmt.Errorf("duplicate github repo %q", r.Name)
+		}
+		names[r.Name] = true
+	}
+	return c.Repos, nil
+}
+
+// enabled is whether there is anything to snapshot from github
+func (c GitHubConfigs) enabled() bool {
+	return len(c.Repos) > 0 || len(c.Trackers) > 0
+}
+
+// TrelloConfigs stores the credentials and boards needed for the Trello API
+type TrelloConfigs struct {
+	trello.TrelloCredentials `yaml:",inline"`
+	// Boards are the boards to snapshot.  If there are none, trello is skipped.
+	Boards []BoardConfigs `yaml:"boards"`
+}
+
+// BoardConfigs stores a single board to snapshot
+type BoardConfigs struct {
+	// Name is what the cards are stored under, ex) the name used by the cards query
+	Name string `yaml:"name"`
+	// ID is the trello id of the board, as seen in the board's url
+	ID string `yaml:"id"`
+	// Prefix only keeps cards whose names start with it.  Empty keeps every card.
+	Prefix string `yaml:"prefix"`
+}
+
+// boards validates that the boards have ids and unique names
+func (c TrelloConfigs) boards() ([]BoardConfigs, error) {
+	names := make(map[string]bool)
+	for _, b := range c.Boards {
+		if b.Name == "" || b.ID == "" {
+			return nil, fmt.Errorf("trello boards must have a name and an id")
+		}
+		if names[b.Name] {
+			return nil, fmt.Errorf("duplicate trello board name %q", b.Name)
+		}
+		names[b.Name] = true
+	}
+	return c.Boards, nil
+}
+
+// Actions taken when a query's bug counts change more than the thresholds allow
+const (
+	anomalyAbort      = "abort"
+	anomalyQuarantine = "quarantine"
+)
+
+// AnomalyThresholds are the limits on how much a bug count may change from the previous snapshot
+// A zero percentage turns that check off
+type AnomalyThresholds struct {
+	// MaxDrop is the largest allowed drop, as a percentage of the previous count
+	MaxDrop float64 `yaml:"max_drop"`
+	// MaxIncrease is the largest allowed increase, as a percentage of the previous count
+	MaxIncrease float64 `yaml:"max_increase"`
+	// MinBugs skips the check when the previous count was smaller, as a few bugs make a big percentage
+	MinBugs int `yaml:"min_bugs"`
+}
+
+// AnomalyConfigs guards against storing a snapshot that is missing bugs, ex) bugzilla returned a partial result
+// The thresholds apply to the total for each query and to each component in the query.
+type AnomalyConfigs struct {
+	AnomalyThresholds `yaml:",inline"`
+	// Action is "abort" (default) to keep the previous bugs, or "quarantine" to also store the new bugs aside for review
+	Action string `yaml:"action"`
+	// Components replaces the thresholds for the named components
+	Components map[string]AnomalyThresholds `yaml:"components"`
+}
+
+// validate checks that the thresholds and action make sense
+func (c AnomalyConfigs) validate() error {
+	if c.Action != "" && c.Action != anomalyAbort && c.Action != anomalyQuarantine {
+		return fmt.Errorf("anomaly action must be %q or %q, got %q", anomalyAbort, anomalyQuarantine, c.Action)
+	}
+	thresholds := map[string]AnomalyThresholds{"": c.AnomalyThresholds}
+	for name, t := range c.Components {
+		thresholds[name] = t
+	}
+	for name, t := range thresholds {
+		if t.MaxDrop < 0 || t.MaxDrop > 100 || t.MaxIncrease < 0 || t.MinBugs < 0 {
+			return fmt.Errorf("invalid anomaly thresholds %+v for component %q", t, name)
+		}
+	}
+	return nil
+}
+
+// quarantine is whether anomalous runs should be quarantined rather than aborted
+func (c AnomalyConfigs) quarantine() bool {
+	return c.Action == anomalyQuarantine
+}
+
+// thresholds returns the thresholds for a component
+func (c AnomalyConfigs) thresholds(component string) AnomalyThresholds {
+	if t, ok := c.Components[component]; ok {
+		return t
+	}
+	return c.AnomalyThresholds
+}
+
+// defaultHourlyRetentionDays is how many days of hourly snapshots are kept if no retention is given
+const defaultHourlyRetentionDays = 7
+
+// HourlyConfigs keeps the bugs from every run of the queries rather than only the last run of each day
+type HourlyConfigs struct {
+	// Enabled stores each run's bugs in the bug_snapshots_hourly table as well as the daily snapshot
+	Enabled bool `yaml:"enabled"`
+	// RetentionDays is how many days of hourly snapshots are kept before they are compacted down to the daily snapshot
+	RetentionDays int `yaml:"retention_days"`
+}
+
+// retention returns how many days of hourly snapshots to keep
+func (c HourlyConfigs) retention() int {
+	if c.RetentionDays <= 0 {
+		return defaultHourlyRetentionDays
+	}
+	return c.RetentionDays
 }
 
 // SourceConfigs struct holds credentials for each API we need to access
 type SourceConfigs struct {
 	Bugzilla BugzillaConfigs `yaml:"bugzilla"`
-	//Trello TrelloConfigs `yaml:"trello"`
+	Jira     JiraConfigs     `yaml:"jira"`
+	GitHub   GitHubConfigs   `yaml:"github"`
+	Trello   TrelloConfigs   `yaml:"trello"`
 }
 
 // Configs holds all of the configuration objects
 type Configs struct {
-	Sources SourceConfigs `yaml:"Sources"`
+	Sources   SourceConfigs  `yaml:"Sources"`
+	Anomalies AnomalyConfigs `yaml:"Anomalies"`
+	Hourly    HourlyConfigs  `yaml:"Hourly"`
 }
 
 // populateConfigs reads the given yaml file and populates the configuration options structs
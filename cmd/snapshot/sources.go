@@ -0,0 +1,163 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/thrasher-redhat/internal-tools/pkg/bugzilla"
+	"github.com/thrasher-redhat/internal-tools/pkg/jira"
+)
+
+// source is an issue tracker whose queries are snapshotted into the bugs table
+// The issues are converted to bugs so that every source is stored and served the same way
+type source interface {
+	// name is stored with every bug from the source, ex) bugzilla or jira
+	name() string
+	// queryNames are the names of the source's queries, in the order they are snapshotted
+	queryNames() []string
+	// query runs the named query and returns the issues it found as bugs
+	query(name string) (bugzilla.Bugs, error)
+}
+
+// bugzillaSource runs the configured bugzilla queries
+type bugzillaSource struct {
+	client  bugzilla.ContextClient
+	configs BugzillaConfigs
+	queries []QueryConfigs
+}
+
+func (s *bugzillaSource) name() string {
+	return bugzilla.Source
+}
+
+func (s *bugzillaSource) queryNames() []string {
+	names := make([]string, len(s.queries))
+	for i, q := range s.queries {
+		names[i] = q.Name
+	}
+	return names
+}
+
+// query runs the search and fetches the comments of each bug unless they are skipped
+func (s *bugzillaSource) query(name string) (bugzilla.Bugs, error) {
+	for _, q := range s.queries {
+		if q.Name != name {
+			continue
+		}
+
+		ctx, cancel := queryContext(s.configs.QueryTimeout)
+		defer cancel()
+
+		bugs, err := executeQuery(ctx, s.client, q, s.configs.Fields)
+		if err != nil {
+			return bugzilla.Bugs{}, err
+		}
+		if !s.configs.SkipComments && len(bugs.Bugs) > 0 {
+			err = addComments(ctx, s.client, bugs)
+			if err != nil {
+				return bugzilla.Bugs{}, err
+			}
+		}
+		return bugs, nil
+	}
+	return bugzilla.Bugs{}, fmt.Errorf("unknown bugzilla query %q", name)
+}
+
+// jiraSource runs the configured JQL queries
+type jiraSource struct {
+	client  jira.Client
+	configs JiraConfigs
+	queries []JiraQueryConfigs
+}
+
+// newJiraClient creates a jira client for the configured instance
+func newJiraClient(c JiraConfigs) (jira.Client, error) {
+	creds := jira.Credentials{
+		Username: c.User,
+		Password: c.Pass,
+		Token:    c.Token,
+	}
+	// Allow the token to come from a secret instead of the configmap
+	if creds.Token == "" {
+		creds.Token = os.Getenv("JIRA_API_TOKEN")
+	}
+
+	httpClient, err := bugzilla.NewHTTPClient(bugzilla.HTTPOptions{
+		Timeout:  c.Timeout,
+		Proxy:    c.Proxy,
+		CABundle: c.CABundle,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return jira.NewClient(creds, c.URL, jira.Options{
+		PageSize:   c.PageSize,
+		HTTPClient: httpClient,
+	}), nil
+}
+
+func (s *jiraSource) name() string {
+	return jira.Source
+}
+
+func (s *jiraSource) queryNames() []string {
+	names := make([]string, len(s.queries))
+	for i, q := range s.queries {
+		names[i] = q.Name
+	}
+	return names
+}
+
+// query runs the JQL search and converts the issues into bugs
+func (s *jiraSource) query(name string) (bugzilla.Bugs, error) {
+	for _, q := range s.queries {
+		if q.Name != name {
+			continue
+		}
+
+		ctx, cancel := queryContext(s.configs.QueryTimeout)
+		defer cancel()
+
+		fields := append(append([]string{}, jira.DefaultFields...), s.configs.Fields...)
+		issues, err := s.client.SearchContext(ctx, q.JQL, fields)
+		if err != nil {
+			return bugzilla.Bugs{}, err
+		}
+		return jira.Bugs(issues)
+	}
+	return bugzilla.Bugs{}, fmt.Errorf("unknown jira query %q", name)
+}
+
+// newSources creates a source for bugzilla and, if it has queries, jira
+// Bugs are stored under their query's name, so query names must be unique across sources
+func newSources(configs SourceConfigs, bugClient bugzilla.ContextClient) ([]source, error) {
+	queries, err := configs.Bugzilla.queries()
+	if err != nil {
+		return nil, fmt.Errorf("invalid bugzilla queries: %v", err)
+	}
+	sources := []source{&bugzillaSource{client: bugClient, configs: configs.Bugzilla, queries: queries}}
+
+	jiraQueries, err := configs.Jira.queries()
+	if err != nil {
+		return nil, fmt.Errorf("invalid jira queries: %v", err)
+	}
+	if len(jiraQueries) > 0 {
+		jiraClient, err := newJiraClient(configs.Jira)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create jira client: %v", err)
+		}
+		sources = append(sources, &jiraSource{client: jiraClient, configs: configs.Jira, queries: jiraQueries})
+	}
+
+	names := make(map[string]string)
+	for _, s := range sources {
+		for _, name := range s.queryNames() {
+			if other, ok := names[name]; ok {
+				return nil, fmt.Errorf("query name %q is used by both %s and %s", name, other, s.name())
+			}
+			names[name] = s.name()
+		}
+	}
+	return sources, nil
+}
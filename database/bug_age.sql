@@ -1 +0,0 @@
-CREATE VIEW bug_age AS SELECT id, MIN(datestamp) FROM bugs GROUP BY id;
\ No newline at end of file
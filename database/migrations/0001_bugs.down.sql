@@ -0,0 +1,2 @@
+-- Reverting this drops every snapshot, so cmd/migrate refuses to unless --to and --force are given
+DROP TABLE IF EXISTS bugs;
@@ -0,0 +1,77 @@
+-- Databases set up by hand before migrations already have these tables, so every statement is idempotent
+
+CREATE TABLE IF NOT EXISTS bugs (
+    id              integer NOT NULL,
+    component       text[] NOT NULL,
+    target_release  text[] NOT NULL,
+    assigned_to     text NOT NULL,
+    status          text NOT NULL,
+    summary         text NOT NULL,
+    keywords        text[] NOT NULL,
+    cf_pm_score     integer NOT NULL,
+    externals       jsonb NOT NULL,
+    datestamp       date NOT NULL,
+    query_name      text NOT NULL DEFAULT 'default',
+    severity        text NOT NULL DEFAULT '',
+    priority        text NOT NULL DEFAULT '',
+    reporter        text NOT NULL DEFAULT '',
+    creation_time   timestamptz,
+    last_change_time timestamptz,
+    resolution      text NOT NULL DEFAULT '',
+    whiteboard      text NOT NULL DEFAULT '',
+    flags           text[] NOT NULL DEFAULT '{}',
+    raw             jsonb NOT NULL DEFAULT '{}',
+    comment_count   integer NOT NULL DEFAULT 0,
+    last_comment_time timestamptz,
+    last_commenter  text NOT NULL DEFAULT '',
+    depends_on      integer[] NOT NULL DEFAULT '{}',
+    blocks          integer[] NOT NULL DEFAULT '{}',
+    dupe_of         integer,
+    clone_of        integer,
+    source          text NOT NULL DEFAULT 'bugzilla',
+    PRIMARY KEY (id, datestamp, query_name, source)
+);
+
+-- Databases created before snapshot queries were named need the query_name column
+ALTER TABLE bugs ADD COLUMN IF NOT EXISTS query_name text NOT NULL DEFAULT 'default';
+
+-- Databases created before components and target releases were lists need them converted
+DO $$
+BEGIN
+    IF (SELECT data_type FROM information_schema.columns WHERE table_name = 'bugs' AND column_name = 'component') = 'text' THEN
+        ALTER TABLE bugs ALTER COLUMN component TYPE text[] USING ARRAY[component];
+        ALTER TABLE bugs ALTER COLUMN target_release TYPE text[] USING ARRAY[target_release];
+    END IF;
+END $$;
+
+-- Databases created before these fields were tracked need the new columns
+ALTER TABLE bugs
+    ADD COLUMN IF NOT EXISTS severity text NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS priority text NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS reporter text NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS creation_time timestamptz,
+    ADD COLUMN IF NOT EXISTS last_change_time timestamptz,
+    ADD COLUMN IF NOT EXISTS resolution text NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS whiteboard text NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS flags text[] NOT NULL DEFAULT '{}';
+
+-- Databases created before the raw bug json was stored need the raw column
+ALTER TABLE bugs ADD COLUMN IF NOT EXISTS raw jsonb NOT NULL DEFAULT '{}';
+
+-- Databases created before comment metadata was stored need the comment columns
+ALTER TABLE bugs
+    ADD COLUMN IF NOT EXISTS comment_count integer NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS last_comment_time timestamptz,
+    ADD COLUMN IF NOT EXISTS last_commenter text NOT NULL DEFAULT '';
+
+-- Databases created before bug relationships were stored need the relationship columns
+ALTER TABLE bugs
+    ADD COLUMN IF NOT EXISTS depends_on integer[] NOT NULL DEFAULT '{}',
+    ADD COLUMN IF NOT EXISTS blocks integer[] NOT NULL DEFAULT '{}',
+    ADD COLUMN IF NOT EXISTS dupe_of integer,
+    ADD COLUMN IF NOT EXISTS clone_of integer;
+
+-- Databases created before other issue trackers were snapshotted need the source column
+-- Issues from different sources may share an id, so the source is part of the primary key
+ALTER TABLE bugs ADD COLUMN IF NOT EXISTS source text NOT NULL DEFAULT 'bugzilla';
+ALTER TABLE bugs DROP CONSTRAINT IF EXISTS bugs_pkey, ADD PRIMARY KEY (id, datestamp, query_name, source);
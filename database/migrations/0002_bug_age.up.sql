@@ -0,0 +1 @@
+CREATE OR REPLACE VIEW bug_age AS SELECT id, MIN(datestamp), source FROM bugs GROUP BY id, source;
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS bug_changes (
+    bug_id          integer NOT NULL,
+    change_time     timestamptz NOT NULL,
+    who             text NOT NULL,
+    field_name      text NOT NULL,
+    removed         text NOT NULL,
+    added           text NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS bug_changes_bug_id ON bug_changes (bug_id, change_time);
@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS cards (
+    id              text NOT NULL,
+    board           text NOT NULL,
+    name            text NOT NULL,
+    list            text NOT NULL,
+    url             text NOT NULL,
+    labels          text[] NOT NULL DEFAULT '{}',
+    members         text[] NOT NULL DEFAULT '{}',
+    due             timestamptz,
+    last_activity   timestamptz,
+    closed          boolean NOT NULL DEFAULT false,
+    datestamp       date NOT NULL,
+    PRIMARY KEY (id, datestamp, board)
+);
+
+-- Databases created before labels, members, and dates were stored need the new columns
+ALTER TABLE cards
+    ADD COLUMN IF NOT EXISTS labels text[] NOT NULL DEFAULT '{}',
+    ADD COLUMN IF NOT EXISTS members text[] NOT NULL DEFAULT '{}',
+    ADD COLUMN IF NOT EXISTS due timestamptz,
+    ADD COLUMN IF NOT EXISTS last_activity timestamptz,
+    ADD COLUMN IF NOT EXISTS closed boolean NOT NULL DEFAULT false;
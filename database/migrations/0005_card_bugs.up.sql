@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS card_bugs (
+    card_id         text NOT NULL,
+    board           text NOT NULL,
+    bug_id          integer NOT NULL,
+    datestamp       date NOT NULL,
+    PRIMARY KEY (card_id, bug_id, datestamp, board)
+);
+
+CREATE INDEX IF NOT EXISTS card_bugs_bug_id ON card_bugs (bug_id, datestamp);
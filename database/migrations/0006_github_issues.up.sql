@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS github_issues (
+    repo            text NOT NULL,
+    number          integer NOT NULL,
+    datestamp       date NOT NULL,
+    title           text NOT NULL,
+    state           text NOT NULL,
+    url             text NOT NULL,
+    pull_request    boolean NOT NULL DEFAULT false,
+    merged_at       timestamptz,
+    labels          text[] NOT NULL DEFAULT '{}',
+    milestone       text NOT NULL DEFAULT '',
+    assignees       text[] NOT NULL DEFAULT '{}',
+    author          text NOT NULL DEFAULT '',
+    created_at      timestamptz,
+    updated_at      timestamptz,
+    closed_at       timestamptz,
+    PRIMARY KEY (repo, number, datestamp)
+);
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS snapshot_runs (
+    id              serial PRIMARY KEY,
+    query_name      text NOT NULL,
+    source          text NOT NULL DEFAULT 'bugzilla',
+    started_at      timestamptz NOT NULL,
+    finished_at     timestamptz NOT NULL,
+    fetch_seconds   double precision NOT NULL DEFAULT 0,
+    bug_count       integer NOT NULL DEFAULT 0,
+    removed_count   integer NOT NULL DEFAULT 0,
+    error           text NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS snapshot_runs_started_at ON snapshot_runs (started_at);
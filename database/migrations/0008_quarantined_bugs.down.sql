@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS quarantined_bugs;
+
+ALTER TABLE snapshot_runs DROP COLUMN IF EXISTS quarantined;
@@ -0,0 +1,11 @@
+-- Runs whose bug counts changed too much from the previous snapshot can be quarantined instead of stored
+ALTER TABLE snapshot_runs ADD COLUMN IF NOT EXISTS quarantined boolean NOT NULL DEFAULT false;
+
+CREATE TABLE IF NOT EXISTS quarantined_bugs (
+    run_id          integer NOT NULL REFERENCES snapshot_runs (id) ON DELETE CASCADE,
+    id              integer NOT NULL,
+    source          text NOT NULL DEFAULT 'bugzilla',
+    component       text[] NOT NULL,
+    raw             jsonb NOT NULL DEFAULT '{}',
+    PRIMARY KEY (run_id, id, source)
+);
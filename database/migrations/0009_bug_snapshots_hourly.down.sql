@@ -0,0 +1 @@
+DROP TABLE IF EXISTS bug_snapshots_hourly;
@@ -0,0 +1,37 @@
+-- Every run's bugs, kept for a few days before they are compacted down to the daily snapshot in the bugs table
+-- The columns are those of bugs as of this migration.  Columns added to bugs later are not kept hourly
+-- unless they are added here by a new migration and to hourlyColumns in pkg/db.
+CREATE TABLE IF NOT EXISTS bug_snapshots_hourly (
+    id              integer NOT NULL,
+    component       text[] NOT NULL,
+    target_release  text[] NOT NULL,
+    assigned_to     text NOT NULL,
+    status          text NOT NULL,
+    summary         text NOT NULL,
+    keywords        text[] NOT NULL,
+    cf_pm_score     integer NOT NULL,
+    externals       jsonb NOT NULL,
+    datestamp       date NOT NULL,
+    query_name      text NOT NULL DEFAULT 'default',
+    severity        text NOT NULL DEFAULT '',
+    priority        text NOT NULL DEFAULT '',
+    reporter        text NOT NULL DEFAULT '',
+    creation_time   timestamptz,
+    last_change_time timestamptz,
+    resolution      text NOT NULL DEFAULT '',
+    whiteboard      text NOT NULL DEFAULT '',
+    flags           text[] NOT NULL DEFAULT '{}',
+    raw             jsonb NOT NULL DEFAULT '{}',
+    comment_count   integer NOT NULL DEFAULT 0,
+    last_comment_time timestamptz,
+    last_commenter  text NOT NULL DEFAULT '',
+    depends_on      integer[] NOT NULL DEFAULT '{}',
+    blocks          integer[] NOT NULL DEFAULT '{}',
+    dupe_of         integer,
+    clone_of        integer,
+    source          text NOT NULL DEFAULT 'bugzilla',
+    snapshot_time   timestamptz NOT NULL,
+    PRIMARY KEY (id, snapshot_time, query_name, source)
+);
+
+CREATE INDEX IF NOT EXISTS bug_snapshots_hourly_query_time ON bug_snapshots_hourly (datestamp, query_name, snapshot_time);
@@ -0,0 +1,41 @@
+-- The differences between consecutive snapshots of each query
+-- previous_datestamp is the snapshot the event is relative to, and is NULL for a query's first snapshot
+-- field_name, old_value, and new_value are only set for changed events
+CREATE TABLE IF NOT EXISTS bug_events (
+    id                  integer NOT NULL,
+    source              text NOT NULL DEFAULT 'bugzilla',
+    query_name          text NOT NULL,
+    datestamp           date NOT NULL,
+    previous_datestamp  date,
+    event               text NOT NULL,
+    field_name          text NOT NULL DEFAULT '',
+    old_value           text NOT NULL DEFAULT '',
+    new_value           text NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS bug_events_query_date ON bug_events (datestamp, query_name);
+CREATE INDEX IF NOT EXISTS bug_events_bug ON bug_events (source, id);
+
+-- Existing snapshots get their appeared and disappeared events, but not their field changes
+WITH snapshots AS (
+    SELECT query_name, datestamp, LAG(datestamp) OVER (PARTITION BY query_name ORDER BY datestamp) AS previous_datestamp
+    FROM (SELECT DISTINCT query_name, datestamp FROM bugs) AS dates
+)
+INSERT INTO bug_events (id, source, query_name, datestamp, previous_datestamp, event)
+SELECT bugs.id, bugs.source, s.query_name, s.datestamp, s.previous_datestamp, 'appeared'
+FROM snapshots s
+JOIN bugs ON bugs.query_name = s.query_name AND bugs.datestamp = s.datestamp
+WHERE NOT EXISTS (
+    SELECT 1 FROM bugs previous
+    WHERE previous.query_name = s.query_name AND previous.datestamp = s.previous_datestamp
+        AND previous.id = bugs.id AND previous.source = bugs.source
+)
+UNION ALL
+SELECT previous.id, previous.source, s.query_name, s.datestamp, s.previous_datestamp, 'disappeared'
+FROM snapshots s
+JOIN bugs previous ON previous.query_name = s.query_name AND previous.datestamp = s.previous_datestamp
+WHERE NOT EXISTS (
+    SELECT 1 FROM bugs
+    WHERE bugs.query_name = s.query_name AND bugs.datestamp = s.datestamp
+        AND bugs.id = previous.id AND bugs.source = previous.source
+);
@@ -0,0 +1 @@
+ALTER TABLE bug_changes DROP COLUMN IF EXISTS fetched_at;
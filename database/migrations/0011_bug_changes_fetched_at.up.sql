@@ -0,0 +1,3 @@
+-- When the bug's history was fetched, so that only bugs changed since then are fetched again
+-- Changes stored before this column existed are fetched again on the next snapshot
+ALTER TABLE bug_changes ADD COLUMN IF NOT EXISTS fetched_at timestamptz;
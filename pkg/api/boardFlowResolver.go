@@ -0,0 +1,17 @@
+package api
+
+// The flow of cards through the lists of a trello board on a single date
+
+type BoardFlowResolver struct {
+	datestamp string
+	lists     []*ListFlowResolver
+}
+
+func (r *BoardFlowResolver) Datestamp() string {
+	return r.datestamp
+}
+
+// Lists are the counts for each list on the board
+func (r *BoardFlowResolver) Lists() []*ListFlowResolver {
+	return r.lists
+}
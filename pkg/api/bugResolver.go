@@ -1,14 +1,17 @@
 package api
 
 import (
-	"encoding/json"
+	"fmt"
 	"log"
+	"time"
 
 	"github.com/thrasher-redhat/internal-tools/pkg/bugzilla"
 )
 
 type BugResolver struct {
 	bug bugzilla.Bug
+	// resolver looks up data stored outside of the bug, such as its history and cards
+	resolver *Resolver
 }
 
 func (r *BugResolver) DateStamp() string {
@@ -19,8 +22,13 @@ func (r *BugResolver) ID() int32 {
 	return int32(r.bug.ID)
 }
 
-func (r *BugResolver) Component() string {
-	return string(r.bug.Component)
+func (r *BugResolver) Component() []string {
+	return r.bug.Component
+}
+
+// PrimaryComponent is the first component, for consumers that expect a single component
+func (r *BugResolver) PrimaryComponent() string {
+	return r.bug.PrimaryComponent()
 }
 
 func (r *BugResolver) Status() string {
@@ -31,8 +39,13 @@ func (r *BugResolver) Summary() string {
 	return r.bug.Summary
 }
 
-func (r *BugResolver) TargetRelease() string {
-	return string(r.bug.TargetRelease)
+func (r *BugResolver) TargetRelease() []string {
+	return r.bug.TargetRelease
+}
+
+// PrimaryTargetRelease is the first target release, for consumers that expect a single release
+func (r *BugResolver) PrimaryTargetRelease() string {
+	return r.bug.PrimaryTargetRelease()
 }
 
 func (r *BugResolver) AssignedTo() string {
@@ -48,27 +61,169 @@ func (r *BugResolver) PmScore() int32 {
 	return int32(r.bug.PmScore)
 }
 
-// external is for parsing the json 'externals' object
-type external struct {
-	ExtBzID int `json:"ext_bz_id"`
-}
-
 // CustomerCase returns if there are any customer cases associated with the bug
 func (r *BugResolver) CustomerCase() bool {
-	// Unmarshal the json with the only field we need
-	var e []external
-	err := json.Unmarshal(r.bug.Externals, &e)
+	return r.bug.Externals.InTrackers(r.resolver.trackers[bugzilla.TrackerCustomerPortal])
+}
+
+// ExternalBugs are the bug's links to other trackers
+func (r *BugResolver) ExternalBugs() []*ExternalBugResolver {
+	ers := make([]*ExternalBugResolver, len(r.bug.Externals))
+	for i, ext := range r.bug.Externals {
+		ers[i] = &ExternalBugResolver{external: ext, datestamp: r.DateStamp(), resolver: r.resolver}
+	}
+	return ers
+}
+
+func (r *BugResolver) Severity() string {
+	return r.bug.Severity
+}
+
+func (r *BugResolver) Priority() string {
+	return r.bug.Priority
+}
+
+// Reporter is the email of the person that filed the bug
+func (r *BugResolver) Reporter() string {
+	return r.bug.Reporter
+}
+
+// CreationTime is when the bug was filed, or null if unknown
+func (r *BugResolver) CreationTime() *string {
+	return formatTime(r.bug.CreationTime)
+}
+
+// LastChangeTime is when the bug was last changed, or null if unknown
+func (r *BugResolver) LastChangeTime() *string {
+	return formatTime(r.bug.LastChangeTime)
+}
+
+func (r *BugResolver) Resolution() string {
+	return r.bug.Resolution
+}
+
+func (r *BugResolver) Whiteboard() string {
+	return r.bug.Whiteboard
+}
+
+// Flags are the flag names and statuses, ex) blocker+
+func (r *BugResolver) Flags() []string {
+	return r.bug.Flags
+}
+
+// formatTime formats a time as RFC3339, using nil for the zero time
+func formatTime(t time.Time) *string {
+	if t.IsZero() {
+		return nil
+	}
+	str := t.Format(time.RFC3339)
+	return &str
+}
+
+// Field returns any field from the raw bugzilla json, ex) a custom cf_* field
+// Returns null if the field wasn't captured by the snapshot
+func (r *BugResolver) Field(args struct{ Name string }) *JSON {
+	value, ok := r.bug.Field(args.Name)
+	if !ok {
+		return nil
+	}
+	return &JSON{raw: value}
+}
+
+// DependsOn is the ids of the bugs that must be fixed before this one
+func (r *BugResolver) DependsOn() []int32 {
+	return toInt32s(r.bug.DependsOn)
+}
+
+// Blocks is the ids of the bugs that are waiting on this one
+func (r *BugResolver) Blocks() []int32 {
+	return toInt32s(r.bug.Blocks)
+}
+
+// DupeOf is the id of the bug this is a duplicate of, or null
+func (r *BugResolver) DupeOf() *int32 {
+	return toNullInt32(int(r.bug.DupeOf))
+}
+
+// CloneOf is the id of the bug this was cloned from, or null
+func (r *BugResolver) CloneOf() *int32 {
+	return toNullInt32(int(r.bug.CloneOf))
+}
+
+// toInt32s converts bug ids for graphql
+func toInt32s(ids []int) []int32 {
+	converted := make([]int32, len(ids))
+	for i, id := range ids {
+		converted[i] = int32(id)
+	}
+	return converted
+}
+
+// toNullInt32 converts a bug id for graphql, using nil for 0
+func toNullInt32(id int) *int32 {
+	if id == 0 {
+		return nil
+	}
+	converted := int32(id)
+	return &converted
+}
+
+// CommentCount is the number of comments, including the description
+func (r *BugResolver) CommentCount() int32 {
+	return int32(r.bug.Comments.Count)
+}
+
+// LastCommentTime is when the latest comment was made, or null if unknown
+func (r *BugResolver) LastCommentTime() *string {
+	return formatTime(r.bug.Comments.LastCommentTime)
+}
+
+// LastCommenter is the email of the person that made the latest comment
+func (r *BugResolver) LastCommenter() string {
+	return r.bug.Comments.LastCommenter
+}
+
+// Source is the tracker the bug came from, ex) bugzilla or jira
+func (r *BugResolver) Source() string {
+	return r.bug.Source
+}
+
+// History is the list of changes made to the bug, oldest first
+// It is empty until the snapshot has fetched the bug's history, and always empty for other sources
+func (r *BugResolver) History() ([]*ChangeResolver, error) {
+	if r.bug.Source != bugzilla.Source {
+		return []*ChangeResolver{}, nil
+	}
+	changes, err := r.resolver.dbClient.GetHistory(r.bug.ID)
 	if err != nil {
-		log.Printf("Unable to unmarshal externals json: %v\n", err)
-		return false
+		log.Printf("Error querying for history of bug %d: %v", r.bug.ID, err)
+		return nil, fmt.Errorf("Error getting history of bug %d", r.bug.ID)
 	}
 
-	for _, ex := range e {
-		if ex.ExtBzID == bugzilla.ExternalID {
-			return true
-		}
+	crs := make([]*ChangeResolver, len(changes))
+	for i, ch := range changes {
+		crs[i] = &ChangeResolver{change: ch}
 	}
-	return false
+	return crs, nil
+}
+
+// Cards are the trello cards that reference the bug on the same date
+// Cards only reference bugzilla bugs, so other sources have no cards
+func (r *BugResolver) Cards() ([]*CardResolver, error) {
+	if r.bug.Source != bugzilla.Source {
+		return []*CardResolver{}, nil
+	}
+	cards, err := r.resolver.dbClient.GetCardsForBug(r.bug.ID, r.DateStamp())
+	if err != nil {
+		log.Printf("Error querying for cards of bug %d: %v", r.bug.ID, err)
+		return nil, fmt.Errorf("Error getting cards for bug %d", r.bug.ID)
+	}
+	return r.resolver.cardResolvers(cards), nil
+}
+
+// QueryName is the snapshot query that found the bug
+func (r *BugResolver) QueryName() string {
+	return r.bug.QueryName
 }
 
 // Age is the number of days since the bug id was first seen
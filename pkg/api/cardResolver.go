@@ -0,0 +1,75 @@
+package api
+
+import (
+	"github.com/thrasher-redhat/internal-tools/pkg/bugzilla"
+	"github.com/thrasher-redhat/internal-tools/pkg/db"
+	"github.com/thrasher-redhat/internal-tools/pkg/trello"
+)
+
+// A single trello card
+
+type CardResolver struct {
+	card trello.Card
+	// resolver looks up the bugs that the card references
+	resolver *Resolver
+}
+
+func (r *CardResolver) ID() string {
+	return r.card.ID
+}
+
+// DateStamp is the date that this card was recorded
+func (r *CardResolver) DateStamp() string {
+	return r.card.DateStamp.Format(dateFormat)
+}
+
+func (r *CardResolver) Name() string {
+	return r.card.Name
+}
+
+// List is the name of the list the card is in
+func (r *CardResolver) List() string {
+	return r.card.List
+}
+
+func (r *CardResolver) URL() string {
+	return r.card.URL
+}
+
+func (r *CardResolver) Labels() []string {
+	return r.card.Labels
+}
+
+// Members are the usernames of the card's members
+func (r *CardResolver) Members() []string {
+	return r.card.Members
+}
+
+// Due is the card's due date, or null if there is none
+func (r *CardResolver) Due() *string {
+	return formatTime(r.card.Due)
+}
+
+// LastActivity is when the card was last changed, or null if unknown
+func (r *CardResolver) LastActivity() *string {
+	return formatTime(r.card.LastActivity)
+}
+
+func (r *CardResolver) Closed() bool {
+	return r.card.Closed
+}
+
+// BugIDs are the ids of the bugzilla bugs referenced by the card
+func (r *CardResolver) BugIDs() []int32 {
+	return toInt32s(r.card.Bugs)
+}
+
+// Bugs are the referenced bugs from the snapshot on the card's date
+// Bugs that no snapshot query found are left out
+func (r *CardResolver) Bugs() ([]*BugResolver, error) {
+	// An empty id filter would match every bug
+	if len(r.card.Bugs) == 0 {
+		return []*BugResolver{}, nil
+	}
+	return r.resolver.getBugs(r.DateStamp(), db.BugFilter{IDs: r.card.Bugs, Sources: []string{bugzilla.Source}})
+}
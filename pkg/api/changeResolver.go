@@ -0,0 +1,35 @@
+package api
+
+import (
+	"time"
+
+	"github.com/thrasher-redhat/internal-tools/pkg/db"
+)
+
+// A single change to a field of a bug
+
+type ChangeResolver struct {
+	change db.BugChange
+}
+
+// When is the time of the change (RFC3339)
+func (r *ChangeResolver) When() string {
+	return r.change.When.Format(time.RFC3339)
+}
+
+// Who is the email of the person that made the change
+func (r *ChangeResolver) Who() string {
+	return r.change.Who
+}
+
+func (r *ChangeResolver) Field() string {
+	return r.change.Field
+}
+
+func (r *ChangeResolver) Removed() string {
+	return r.change.Removed
+}
+
+func (r *ChangeResolver) Added() string {
+	return r.change.Added
+}
@@ -0,0 +1,119 @@
+package api
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/thrasher-redhat/internal-tools/pkg/db"
+)
+
+// defaultDependencyDepth is how many levels of dependencies are followed if no depth is given
+const defaultDependencyDepth = 3
+
+// maxDependencyDepth limits how many levels of dependencies can be requested at once
+const maxDependencyDepth = 10
+
+// A bug in a dependency tree, along with the bugs it depends on
+
+type DependencyNodeResolver struct {
+	id int
+	// bug is nil if the bug isn't in the snapshot
+	bug       *BugResolver
+	dependsOn []*DependencyNodeResolver
+}
+
+func (r *DependencyNodeResolver) ID() int32 {
+	return int32(r.id)
+}
+
+// Bug is the snapshot of the bug, or nil if no snapshot query found it
+func (r *DependencyNodeResolver) Bug() *BugResolver {
+	return r.bug
+}
+
+func (r *DependencyNodeResolver) DependsOn() []*DependencyNodeResolver {
+	return r.dependsOn
+}
+
+// getDependencyTree builds the tree of bugs that the given bug depends on, a level at a time
+// A bug that already appears in the tree is included again but its dependencies are not, which stops cycles
+func (r *Resolver) getDependencyTree(datestamp string, id int, depth int) (*DependencyNodeResolver, error) {
+	root := &DependencyNodeResolver{id: id}
+	bugs := make(map[int]*BugResolver)
+	seen := map[int]bool{id: true}
+	level := []*DependencyNodeResolver{root}
+
+	for i := 0; i <= depth && len(level) > 0; i++ {
+		ids := make([]int, len(level))
+		for j, node := range level {
+			ids[j] = node.id
+		}
+		brs, err := r.getBugs(datestamp, db.BugFilter{IDs: ids})
+		if err != nil {
+			return nil, err
+		}
+		for _, br := range brs {
+			bugs[br.bug.ID] = br
+		}
+
+		var next []*DependencyNodeResolver
+		for _, node := range level {
+			// Stop at the last level, or if we don't know what the bug depends on
+			bug, ok := bugs[node.id]
+			if i == depth || !ok {
+				continue
+			}
+			for _, dep := range bug.bug.DependsOn {
+				child := &DependencyNodeResolver{id: dep}
+				node.dependsOn = append(node.dependsOn, child)
+				if !seen[dep] {
+					seen[dep] = true
+					next = append(next, child)
+				}
+			}
+		}
+		level = next
+	}
+
+	setBugs(root, bugs)
+	return root, nil
+}
+
+// setBugs sets the bug of each node in the tree, including repeated bugs
+func setBugs(node *DependencyNodeResolver, bugs map[int]*BugResolver) {
+	node.bug = bugs[node.id]
+	for _, child := range node.dependsOn {
+		setBugs(child, bugs)
+	}
+}
+
+// DependencyTree is a graphql query that fetches the tree of bugs that the given bug depends on from the latest snapshot
+func (r *Resolver) DependencyTree(args struct {
+	ID    int32
+	Depth *int32
+}) (*DependencyNodeResolver, error) {
+
+	// Parse input
+	depth := defaultDependencyDepth
+	if args.Depth != nil {
+		depth = int(*args.Depth)
+	}
+	if depth < 0 || depth > maxDependencyDepth {
+		return nil, fmt.Errorf("Depth must be between 0 and %d, got %d", maxDependencyDepth, depth)
+	}
+	date, err := r.parseDatestamp("_latest")
+	if err != nil {
+		safe, err := safeError(err, "Unable to get latest date")
+		log.Printf("Error parsing date: %v", err)
+		return nil, safe
+	}
+
+	tree, err := r.getDependencyTree(date, int(args.ID), depth)
+	if err != nil {
+		safe, err := safeError(err, "Error getting dependencies of bug %d", args.ID)
+		log.Printf("Error querying for dependency tree: %v", err)
+		return nil, safe
+	}
+
+	return tree, nil
+}
@@ -0,0 +1,110 @@
+package api
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/thrasher-redhat/internal-tools/pkg/bugzilla"
+	"github.com/thrasher-redhat/internal-tools/pkg/github"
+)
+
+// trackers maps the meaning of an external tracker (customer_portal, github, jira, errata...) to its tracker ids
+type trackers map[string][]int
+
+// newTrackers copies the configured trackers
+// The customer portal defaults to bugzilla.ExternalID if it isn't configured
+func newTrackers(configured map[string][]int) trackers {
+	t := make(trackers, len(configured)+1)
+	for meaning, ids := range configured {
+		t[meaning] = ids
+	}
+	if len(t[bugzilla.TrackerCustomerPortal]) == 0 {
+		t[bugzilla.TrackerCustomerPortal] = []int{bugzilla.ExternalID}
+	}
+	return t
+}
+
+// meaning returns the configured meaning of the tracker id, or nil if it isn't configured
+func (t trackers) meaning(id int) *string {
+	for meaning, ids := range t {
+		for _, trackerID := range ids {
+			if trackerID == id {
+				m := meaning
+				return &m
+			}
+		}
+	}
+	return nil
+}
+
+// ids returns the tracker ids for the given meanings
+func (t trackers) ids(meanings []string) ([]int, error) {
+	var ids []int
+	for _, meaning := range meanings {
+		trackerIDs, ok := t[meaning]
+		if !ok {
+			err := fmt.Errorf("resolver: unknown external tracker %q", meaning)
+			return nil, newAPISafeError(err, "Unknown external tracker %q", meaning)
+		}
+		ids = append(ids, trackerIDs...)
+	}
+	return ids, nil
+}
+
+// A link from a bug to an issue in another tracker
+
+type ExternalBugResolver struct {
+	external bugzilla.ExternalBug
+	// datestamp is the date of the bug that links to the external bug
+	datestamp string
+	resolver  *Resolver
+}
+
+func (r *ExternalBugResolver) TrackerID() int32 {
+	return int32(r.external.TrackerID)
+}
+
+func (r *ExternalBugResolver) TrackerName() string {
+	return r.external.TrackerName
+}
+
+// Tracker is the configured meaning of the tracker, ex) customer_portal
+func (r *ExternalBugResolver) Tracker() *string {
+	return r.resolver.trackers.meaning(r.external.TrackerID)
+}
+
+func (r *ExternalBugResolver) ExternalID() string {
+	return r.external.ExternalID
+}
+
+func (r *ExternalBugResolver) Status() string {
+	return r.external.Status
+}
+
+func (r *ExternalBugResolver) Priority() string {
+	return r.external.Priority
+}
+
+// GitHub is the linked github issue or pull request as it was snapshotted on (or most recently before) the bug's date
+// It is null if the external bug doesn't point at github or the issue was never snapshotted.
+// If github trackers are configured, only external bugs in those trackers are looked up.
+func (r *ExternalBugResolver) GitHub() (*GitHubIssueResolver, error) {
+	if ids, ok := r.resolver.trackers[bugzilla.TrackerGitHub]; ok && !r.external.InTrackers(ids) {
+		return nil, nil
+	}
+	ref, ok := github.ParseRef(r.external.ExternalID)
+	if !ok {
+		return nil, nil
+	}
+
+	issue, err := r.resolver.dbClient.GetGitHubIssue(ref.Repo, ref.Number, r.datestamp)
+	if err != nil {
+		safe, err := safeError(err, "Error getting github issue %v", ref)
+		log.Printf("Error querying for github issue %v: %v", ref, err)
+		return nil, safe
+	}
+	if issue == nil {
+		return nil, nil
+	}
+	return &GitHubIssueResolver{issue: *issue}, nil
+}
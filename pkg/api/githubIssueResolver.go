@@ -0,0 +1,84 @@
+package api
+
+import (
+	"github.com/thrasher-redhat/internal-tools/pkg/github"
+)
+
+// A single github issue or pull request
+
+type GitHubIssueResolver struct {
+	issue github.Issue
+}
+
+// Repo is the owner and name of the repository, ex) openshift/origin
+func (r *GitHubIssueResolver) Repo() string {
+	return r.issue.Repo
+}
+
+func (r *GitHubIssueResolver) Number() int32 {
+	return int32(r.issue.Number)
+}
+
+// DateStamp is the date that this issue was recorded
+func (r *GitHubIssueResolver) DateStamp() string {
+	return r.issue.DateStamp.Format(dateFormat)
+}
+
+func (r *GitHubIssueResolver) Title() string {
+	return r.issue.Title
+}
+
+// State is open or closed.  A merged pull request is closed.
+func (r *GitHubIssueResolver) State() string {
+	return r.issue.State
+}
+
+func (r *GitHubIssueResolver) URL() string {
+	return r.issue.URL
+}
+
+func (r *GitHubIssueResolver) PullRequest() bool {
+	return r.issue.PullRequest
+}
+
+// Merged is whether the issue is a pull request that has been merged
+func (r *GitHubIssueResolver) Merged() bool {
+	return r.issue.Merged()
+}
+
+// MergedAt is when the pull request was merged, or null if it wasn't
+func (r *GitHubIssueResolver) MergedAt() *string {
+	return formatTime(r.issue.MergedAt)
+}
+
+func (r *GitHubIssueResolver) Labels() []string {
+	return r.issue.Labels
+}
+
+// Milestone is the title of the issue's milestone, or empty if there is none
+func (r *GitHubIssueResolver) Milestone() string {
+	return r.issue.Milestone
+}
+
+// Assignees are the logins of the issue's assignees
+func (r *GitHubIssueResolver) Assignees() []string {
+	return r.issue.Assignees
+}
+
+// Author is the login of the user that opened the issue
+func (r *GitHubIssueResolver) Author() string {
+	return r.issue.Author
+}
+
+func (r *GitHubIssueResolver) CreatedAt() *string {
+	return formatTime(r.issue.CreatedAt)
+}
+
+func (r *GitHubIssueResolver) UpdatedAt() *string {
+	return formatTime(r.issue.UpdatedAt)
+}
+
+// ClosedAt is when the issue was closed, or null if it is open
+func (r *GitHubIssueResolver) ClosedAt() *string {
+	return formatTime(r.issue.ClosedAt)
+}
@@ -0,0 +1,35 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// JSON is a graphql scalar holding any json value
+// It is used for fields that aren't in the schema, such as bugzilla custom fields
+type JSON struct {
+	raw json.RawMessage
+}
+
+// ImplementsGraphQLType maps JSON to the JSON scalar in the schema
+func (JSON) ImplementsGraphQLType(name string) bool {
+	return name == "JSON"
+}
+
+// UnmarshalGraphQL accepts any input value
+func (j *JSON) UnmarshalGraphQL(input interface{}) error {
+	raw, err := json.Marshal(input)
+	if err != nil {
+		return fmt.Errorf("unable to marshal JSON input: %v", err)
+	}
+	j.raw = raw
+	return nil
+}
+
+// MarshalJSON writes the raw json as is
+func (j JSON) MarshalJSON() ([]byte, error) {
+	if len(j.raw) == 0 {
+		return []byte("null"), nil
+	}
+	return j.raw, nil
+}
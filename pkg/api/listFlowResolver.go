@@ -0,0 +1,30 @@
+package api
+
+import (
+	"github.com/thrasher-redhat/internal-tools/pkg/db"
+)
+
+// The cards in a single trello list compared to the previous snapshot of the board
+
+type ListFlowResolver struct {
+	flow db.ListFlow
+}
+
+func (r *ListFlowResolver) List() string {
+	return r.flow.List
+}
+
+// Total is the number of cards in the list
+func (r *ListFlowResolver) Total() int32 {
+	return int32(r.flow.Total)
+}
+
+// Entered is the number of cards that moved into the list, or were added to the board, since the previous snapshot
+func (r *ListFlowResolver) Entered() int32 {
+	return int32(r.flow.Entered)
+}
+
+// Left is the number of cards that moved out of the list, or off the board, since the previous snapshot
+func (r *ListFlowResolver) Left() int32 {
+	return int32(r.flow.Left)
+}
@@ -3,10 +3,13 @@ package api
 import (
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
+	"github.com/thrasher-redhat/internal-tools/pkg/bugzilla"
 	"github.com/thrasher-redhat/internal-tools/pkg/db"
 	"github.com/thrasher-redhat/internal-tools/pkg/options"
+	"github.com/thrasher-redhat/internal-tools/pkg/trello"
 )
 
 // All dates should use the YYYY-MM-DD format
@@ -17,10 +20,12 @@ type Resolver struct {
 	dbClient db.Client
 	releases map[string]options.Release
 	blockers []string
+	trackers trackers
 }
 
 // NewResolver is a factory for Resolver
-func NewResolver(db db.Client, releases []options.Release, blockers []string) (*Resolver, error) {
+// The trackers map the meaning of external trackers (customer_portal, github, jira, errata...) to their ids
+func NewResolver(db db.Client, releases []options.Release, blockers []string, trackers map[string][]int) (*Resolver, error) {
 
 	// Create a map of releases using the release name as the key
 	m := make(map[string]options.Release)
@@ -32,6 +37,7 @@ func NewResolver(db db.Client, releases []options.Release, blockers []string) (*
 		dbClient: db,
 		releases: m,
 		blockers: blockers,
+		trackers: newTrackers(trackers),
 	}, nil
 }
 
@@ -51,6 +57,15 @@ func (r Resolver) parseDatestamp(datestamp string) (string, error) {
 			return "", newAPISafeError(err, "Error retreiving earliest date")
 		}
 		datestamp = date.Format(dateFormat)
+	default:
+		// A time picks the hourly snapshot taken at or before it, ex) to watch the bugs on release day
+		if strings.Contains(datestamp, "T") {
+			t, err := time.Parse(time.RFC3339, datestamp)
+			if err != nil {
+				return "", newAPISafeError(err, "Invalid time %q, expected RFC3339 (ex: 2018-06-01T15:00:00Z)", datestamp)
+			}
+			datestamp = t.Format(time.RFC3339)
+		}
 	}
 	return datestamp, nil
 }
@@ -69,19 +84,88 @@ func parseComponents(components *[]string) []string {
 	// https://github.com/graph-gophers/graphql-go/blob/b46637030579abd312c5eea21d36845b6e9e7ca4/internal/exec/packer/packer.go#L248-L250
 
 	// If graphql-go gets updated, we should update the glide files and remove this
-	var c []string
-	if components != nil && len(*components) > 0 {
-		c = *components
-	} else {
-		c = nil
+	return parseList(components)
+}
+
+// parseList dereferences an optional list argument, using nil for an empty list.
+// See parseComponents for why lists are passed as pointers.
+func parseList(list *[]string) []string {
+	if list != nil && len(*list) > 0 {
+		return *list
+	}
+	return nil
+}
+
+// parseQueryName dereferences the optional query name, where empty means all queries
+func parseQueryName(queryName *string) string {
+	if queryName == nil {
+		return ""
+	}
+	return *queryName
+}
+
+// filterArgs are the filter arguments shared by the bug and rollup queries
+type filterArgs struct {
+	Components *[]string
+	QueryName  *string
+	Severities *[]string
+	Priorities *[]string
+	Flags      *[]string
+	// CustomFields is a list to work around the same bug as parseComponents
+	CustomFields     *[]customFieldArgs
+	ExternalBugs     *[]string
+	ExternalTrackers *[]string
+	Sources          *[]string
+}
+
+// customFieldArgs is a filter on a field from the raw bugzilla json
+type customFieldArgs struct {
+	Name   string
+	Values *[]string
+}
+
+// parseCustomFields converts the custom field arguments into database filters
+// Filters without any values are ignored
+func parseCustomFields(fields *[]customFieldArgs) []db.FieldFilter {
+	if fields == nil {
+		return nil
+	}
+	var filters []db.FieldFilter
+	for _, f := range *fields {
+		values := parseList(f.Values)
+		if len(values) == 0 {
+			continue
+		}
+		filters = append(filters, db.FieldFilter{Name: f.Name, Values: values})
+	}
+	return filters
+}
+
+// filter parses the arguments into a database filter
+// External trackers are given by meaning and looked up in the configured trackers
+func (r *Resolver) filter(a filterArgs) (db.BugFilter, error) {
+	trackerIDs, err := r.trackers.ids(parseList(a.ExternalTrackers))
+	if err != nil {
+		return db.BugFilter{}, err
 	}
-	return c
+
+	return db.BugFilter{
+		QueryName:        parseQueryName(a.QueryName),
+		Components:       parseComponents(a.Components),
+		Severities:       parseList(a.Severities),
+		Priorities:       parseList(a.Priorities),
+		Flags:            parseList(a.Flags),
+		CustomFields:     parseCustomFields(a.CustomFields),
+		ExternalBugs:     parseList(a.ExternalBugs),
+		ExternalTrackers: trackerIDs,
+		Sources:          parseList(a.Sources),
+	}, nil
 }
 
 // getBugs queries the database for a list of bugs and converts to BugResolvers
-func (r *Resolver) getBugs(datestamp string, components []string) ([]*BugResolver, error) {
+func (r *Resolver) getBugs(datestamp string, filter db.BugFilter) ([]*BugResolver, error) {
 	// Query the database
-	bugs, err := r.dbClient.GetBugs(datestamp, components)
+	bugs, err := r.dbClient.GetBugs(datestamp, filter)
 	if err != nil {
 		return nil, newAPISafeError(err, "Error querying for list of bugs")
 	}
@@ -89,15 +173,15 @@ func (r *Resolver) getBugs(datestamp string, components []string) ([]*BugResolve
 	// Convert bugs into BugResolvers
 	brs := make([]*BugResolver, len(bugs))
 	for i, b := range bugs {
-		brs[i] = &BugResolver{bug: b}
+		brs[i] = &BugResolver{bug: b, resolver: r}
 	}
 	return brs, nil
 }
 
 // Bugs is a graphql query that fetches a list of bugs
 func (r *Resolver) Bugs(args struct {
-	Datestamp  string
-	Components *[]string
+	Datestamp string
+	filterArgs
 }) ([]*BugResolver, error) {
 
 	// Parse input
@@ -107,10 +191,15 @@ func (r *Resolver) Bugs(args struct {
 		log.Printf("Error parsing date: %v", err)
 		return nil, safe
 	}
-	components := parseComponents(args.Components)
+	filter, err := r.filter(args.filterArgs)
+	if err != nil {
+		safe, err := safeError(err, "Invalid filter")
+		log.Printf("Error parsing filter: %v", err)
+		return nil, safe
+	}
 
 	// Query the database for a list of bugs given the datestamp/components)
-	bugs, err := r.getBugs(date, components)
+	bugs, err := r.getBugs(date, filter)
 	if err != nil {
 		safe, err := safeError(err, "Error getting list of bugs")
 		log.Printf("Error querying for bugs: %v", err)
@@ -120,10 +209,204 @@ func (r *Resolver) Bugs(args struct {
 	return bugs, nil
 }
 
+// StaleBugs is a graphql query that fetches the latest bugs that nobody has changed or commented on for the given number of days
+func (r *Resolver) StaleBugs(args struct {
+	Days       int32
+	Components *[]string
+}) ([]*BugResolver, error) {
+
+	// Parse input
+	if args.Days <= 0 {
+		err := fmt.Errorf("resolver: invalid number of days %d", args.Days)
+		return nil, newAPISafeError(err, "days must be positive, got %d", args.Days)
+	}
+	date, err := r.parseDatestamp("_latest")
+	if err != nil {
+		safe, err := safeError(err, "Unable to get latest date")
+		log.Printf("Error parsing date: %v", err)
+		return nil, safe
+	}
+	filter := db.BugFilter{
+		Components:     parseComponents(args.Components),
+		UntouchedSince: time.Now().AddDate(0, 0, -int(args.Days)),
+	}
+
+	bugs, err := r.getBugs(date, filter)
+	if err != nil {
+		safe, err := safeError(err, "Error getting list of stale bugs")
+		log.Printf("Error querying for stale bugs: %v", err)
+		return nil, safe
+	}
+
+	return bugs, nil
+}
+
+// Cards is a graphql query that fetches the cards on a trello board for a given date (defaults to the board's latest snapshot)
+func (r *Resolver) Cards(args struct {
+	Board     string
+	Datestamp *string
+}) ([]*CardResolver, error) {
+
+	// Parse input
+	// The latest date for bugs may not have cards, so leave the latest date to the board
+	datestamp := ""
+	if args.Datestamp != nil && *args.Datestamp != "_latest" {
+		date, err := r.parseDatestamp(*args.Datestamp)
+		if err != nil {
+			safe, err := safeError(err, "Unable to parse date %q", *args.Datestamp)
+			log.Printf("Error parsing date: %v", err)
+			return nil, safe
+		}
+		datestamp = date
+	}
+
+	cards, err := r.dbClient.GetCards(args.Board, datestamp)
+	if err != nil {
+		safe, err := safeError(err, "Error getting cards for board %q", args.Board)
+		log.Printf("Error querying for cards: %v", err)
+		return nil, safe
+	}
+
+	return r.cardResolvers(cards), nil
+}
+
+// BoardFlow is a graphql query for the flow of cards through a board's lists on each snapshot date
+// The end defaults to the board's latest snapshot and the start to 9 weeks (3 sprints) before the end.
+// Each date is compared to the board's previous snapshot, so the first snapshot has nothing entered or left.
+func (r *Resolver) BoardFlow(args struct {
+	Board string
+	Start *string
+	End   *string
+}) ([]*BoardFlowResolver, error) {
+
+	dates, err := r.dbClient.GetCardDates(args.Board)
+	if err != nil {
+		safe, err := safeError(err, "Error getting dates for board %q", args.Board)
+		log.Printf("Error querying for card dates: %v", err)
+		return nil, safe
+	}
+	if len(dates) == 0 {
+		err := fmt.Errorf("resolver: no card dates for board %q", args.Board)
+		return nil, newAPISafeError(err, "No cards found for board %q", args.Board)
+	}
+
+	// Parse input
+	// The latest and earliest dates for bugs may not have cards, so those are left to the board
+	endDate := dates[len(dates)-1]
+	if args.End != nil && *args.End != "_latest" {
+		endDate, err = r.parseBoardDate(*args.End)
+		if err != nil {
+			safe, err := safeError(err, "Unable to parse date %q", *args.End)
+			log.Printf("Error parsing date: %v", err)
+			return nil, safe
+		}
+	}
+	startDate := endDate.AddDate(0, 0, -63)
+	if args.Start != nil && *args.Start != "_earliest" {
+		startDate, err = r.parseBoardDate(*args.Start)
+		if err != nil {
+			safe, err := safeError(err, "Unable to parse date %q", *args.Start)
+			log.Printf("Error parsing date: %v", err)
+			return nil, safe
+		}
+	} else if args.Start != nil {
+		startDate = dates[0]
+	}
+	if endDate.Before(startDate) {
+		err := fmt.Errorf("resolver: end date %v is before start date %v", endDate, startDate)
+		return nil, newAPISafeError(err, "End date %q cannot be before start date %q", endDate.Format(dateFormat), startDate.Format(dateFormat))
+	}
+
+	var flows []*BoardFlowResolver
+	for i, d := range dates {
+		if d.Before(startDate) || d.After(endDate) {
+			continue
+		}
+		previous := d
+		if i > 0 {
+			previous = dates[i-1]
+		}
+
+		lists, err := r.dbClient.GetListFlow(args.Board, previous.Format(dateFormat), d.Format(dateFormat))
+		if err != nil {
+			safe, err := safeError(err, "Error getting flow for board %q on %q", args.Board, d.Format(dateFormat))
+			log.Printf("Error querying for list flow: %v", err)
+			return nil, safe
+		}
+
+		lfrs := make([]*ListFlowResolver, len(lists))
+		for j, l := range lists {
+			lfrs[j] = &ListFlowResolver{flow: l}
+		}
+		flows = append(flows, &BoardFlowResolver{
+			datestamp: d.Format(dateFormat),
+			lists:     lfrs,
+		})
+	}
+
+	return flows, nil
+}
+
+// parseBoardDate parses a date for the board queries, using the day of a time (RFC3339) as boards only have daily snapshots
+func (r *Resolver) parseBoardDate(datestamp string) (time.Time, error) {
+	date, err := r.parseDatestamp(datestamp)
+	if err != nil {
+		return time.Time{}, err
+	}
+	t, err := time.Parse(dateFormat, db.SnapshotDate(date))
+	if err != nil {
+		return time.Time{}, newAPISafeError(err, "Invalid date %q, expected YYYY-MM-DD (ex: 2018-06-01)", datestamp)
+	}
+	return t, nil
+}
+
+// GitHubIssues is a graphql query for the snapshotted issues and pull requests of a github repository
+// The issues linked from bugzilla are included along with the repository's open issues.
+func (r *Resolver) GitHubIssues(args struct {
+	Repo      string
+	Datestamp *string
+}) ([]*GitHubIssueResolver, error) {
+
+	// Parse input
+	// The latest date for bugs may not have issues, so leave the latest date to the repository
+	datestamp := ""
+	if args.Datestamp != nil && *args.Datestamp != "_latest" {
+		date, err := r.parseDatestamp(*args.Datestamp)
+		if err != nil {
+			safe, err := safeError(err, "Unable to parse date %q", *args.Datestamp)
+			log.Printf("Error parsing date: %v", err)
+			return nil, safe
+		}
+		datestamp = date
+	}
+
+	issues, err := r.dbClient.GetGitHubIssues(args.Repo, datestamp)
+	if err != nil {
+		safe, err := safeError(err, "Error getting github issues for repo %q", args.Repo)
+		log.Printf("Error querying for github issues: %v", err)
+		return nil, safe
+	}
+
+	girs := make([]*GitHubIssueResolver, len(issues))
+	for i, issue := range issues {
+		girs[i] = &GitHubIssueResolver{issue: issue}
+	}
+	return girs, nil
+}
+
+// cardResolvers converts cards into CardResolvers
+func (r *Resolver) cardResolvers(cards []trello.Card) []*CardResolver {
+	crs := make([]*CardResolver, len(cards))
+	for i, card := range cards {
+		crs[i] = &CardResolver{card: card, resolver: r}
+	}
+	return crs
+}
+
 // Snapshot grabs the list of bugs and rollup for a given date
 func (r *Resolver) Snapshot(args struct {
-	Datestamp  string
-	Components *[]string
+	Datestamp string
+	filterArgs
 }) (*SnapshotResolver, error) {
 
 	// Parse input
@@ -133,10 +416,15 @@ func (r *Resolver) Snapshot(args struct {
 		log.Printf("Error parsing date: %v", err)
 		return nil, safe
 	}
-	components := parseComponents(args.Components)
+	filter, err := r.filter(args.filterArgs)
+	if err != nil {
+		safe, err := safeError(err, "Invalid filter")
+		log.Printf("Error parsing filter: %v", err)
+		return nil, safe
+	}
 
 	// Grab the list of bugs (as bugResolvers)
-	brs, err := r.getBugs(date, components)
+	brs, err := r.getBugs(date, filter)
 	if err != nil {
 		safe, err := safeError(err, "Error getting list of bugs")
 		log.Printf("Error querying for bugs: %v", err)
@@ -145,7 +433,7 @@ func (r *Resolver) Snapshot(args struct {
 
 	// Get the rollup for the current date
 	// We will NOT filter by targetRelease for snapshot
-	ru, err := r.getRollup(date, components, nil)
+	ru, err := r.getRollup(date, filter)
 	if err != nil {
 		safe, underlying := safeError(err, "Error getting rollup for date %q", date)
 		log.Printf("Error getting snapshot rollup: %v", underlying)
@@ -162,10 +450,10 @@ func (r *Resolver) Snapshot(args struct {
 
 // getRollup fetches date's totals for all (total), new, and closed bugs
 // for each of all bugs, blocker bugs, and bugs with customer cases.
-// Filters on components and targetRelease if provided.
+// Filters on query name, components, and targetRelease if provided.
 // We assume that the inputs have already been parsed.
 // Returns nil if the given datestamp has no bugs.
-func (r *Resolver) getRollup(datestamp string, components []string, targets []string) (*RollupResolver, error) {
+func (r *Resolver) getRollup(datestamp string, filter db.BugFilter) (*RollupResolver, error) {
 	// Get previous date to compare for new/closed bugs
 	previousTime, err := r.dbClient.GetPreviousDate(datestamp)
 	if err != nil {
@@ -174,15 +462,19 @@ func (r *Resolver) getRollup(datestamp string, components []string, targets []st
 	previous := previousTime.Format(dateFormat)
 
 	// Get the breakdowns for each set
-	all, err := r.dbClient.GetBreakdown(previous, datestamp, components, nil, false, targets)
+	all, err := r.dbClient.GetBreakdown(previous, datestamp, filter)
 	if err != nil {
 		return nil, fmt.Errorf("unable to get breakdown: %v", err)
 	}
-	blockers, err := r.dbClient.GetBreakdown(previous, datestamp, components, r.blockers, false, targets)
+	blockerFilter := filter
+	blockerFilter.Keywords = r.blockers
+	blockers, err := r.dbClient.GetBreakdown(previous, datestamp, blockerFilter)
 	if err != nil {
 		return nil, fmt.Errorf("unable to get breakdown: %v", err)
 	}
-	custCases, err := r.dbClient.GetBreakdown(previous, datestamp, components, nil, true, targets)
+	custCaseFilter := filter
+	custCaseFilter.CustomerCaseTrackers = r.trackers[bugzilla.TrackerCustomerPortal]
+	custCases, err := r.dbClient.GetBreakdown(previous, datestamp, custCaseFilter)
 	if err != nil {
 		return nil, fmt.Errorf("unable to get breakdown: %v", err)
 	}
@@ -198,12 +490,12 @@ func (r *Resolver) getRollup(datestamp string, components []string, targets []st
 // getRollups gets a list of rollups for every day between startDate and endDate
 // Dates with no data will be skipped
 // Input is assumed to be parsed
-func (r Resolver) getRollups(startDate, endDate time.Time, components, targets []string) ([]*RollupResolver, error) {
+func (r Resolver) getRollups(startDate, endDate time.Time, filter db.BugFilter) ([]*RollupResolver, error) {
 	var rollups []*RollupResolver
 
 	// Loop over days between start and end date (inclusive)
 	for d := startDate; !d.Equal(endDate.AddDate(0, 0, 1)); d = d.AddDate(0, 0, 1) {
-		rollup, err := r.getRollup(d.Format(dateFormat), components, targets)
+		rollup, err := r.getRollup(d.Format(dateFormat), filter)
 		if err != nil || rollup == nil {
 			// Don't hard error if an individual rollup fails or doesn't exist
 			// Simply skip it and move on
@@ -215,8 +507,9 @@ func (r Resolver) getRollups(startDate, endDate time.Time, components, targets [
 	return rollups, nil
 }
 
-// getRelease is a helper function to get a release rollup for the given release/components
-func (r Resolver) getRelease(name string, components []string) (*ReleaseResolver, error) {
+// getRelease is a helper function to get a release rollup for the given release/filter
+// The filter's target releases are replaced by the release's targets
+func (r Resolver) getRelease(name string, filter db.BugFilter) (*ReleaseResolver, error) {
 	// Lookup release info by name
 	thisRelease, ok := r.releases[name]
 	if !ok {
@@ -272,7 +565,8 @@ func (r Resolver) getRelease(name string, components []string) (*ReleaseResolver
 		return nil, newAPISafeError(err, "invalid dates for release %q", name)
 	}
 
-	rollups, err := r.getRollups(startDate, endDate, components, thisRelease.Targets)
+	filter.TargetReleases = thisRelease.Targets
+	rollups, err := r.getRollups(startDate, endDate, filter)
 	if err != nil {
 		return nil, fmt.Errorf("release: error getting list of rollups: %v", err)
 	}
@@ -285,14 +579,19 @@ func (r Resolver) getRelease(name string, components []string) (*ReleaseResolver
 
 // Release creates, populates, and returns a ReleaseResolver
 func (r Resolver) Release(args struct {
-	Name       string
-	Components *[]string
+	Name string
+	filterArgs
 }) (*ReleaseResolver, error) {
 
 	// Parse input
-	components := parseComponents(args.Components)
+	filter, err := r.filter(args.filterArgs)
+	if err != nil {
+		safe, err := safeError(err, "Invalid filter")
+		log.Printf("Error parsing filter: %v", err)
+		return nil, safe
+	}
 
-	release, err := r.getRelease(args.Name, components)
+	release, err := r.getRelease(args.Name, filter)
 	if err != nil {
 		safe, underlying := safeError(err, "Error querying for release %q", args.Name)
 		log.Printf("Error getting release information: %v", underlying)
@@ -303,9 +602,16 @@ func (r Resolver) Release(args struct {
 }
 
 // Rollups creates and returns a list of rollups over the past 3 sprints (9 weeks).
-func (r Resolver) Rollups(args struct{ Components *[]string }) ([]*RollupResolver, error) {
+func (r Resolver) Rollups(args struct {
+	filterArgs
+}) ([]*RollupResolver, error) {
 	// Parse input and setup dates
-	components := parseComponents(args.Components)
+	filter, err := r.filter(args.filterArgs)
+	if err != nil {
+		safe, err := safeError(err, "Invalid filter")
+		log.Printf("Error parsing filter: %v", err)
+		return nil, safe
+	}
 
 	endDate, err := r.dbClient.GetLatest()
 	if err != nil {
@@ -317,7 +623,7 @@ func (r Resolver) Rollups(args struct{ Components *[]string }) ([]*RollupResolve
 	// Start the graph data 9 weeks (3 sprints) before the end date.
 	startDate := endDate.AddDate(0, 0, -63)
 
-	rollups, err := r.getRollups(startDate, endDate, components, nil)
+	rollups, err := r.getRollups(startDate, endDate, filter)
 	if err != nil {
 		safe, underlying := safeError(err, "Unable to get list of rollup data")
 		log.Printf("Error getting rollups: %v", underlying)
@@ -328,13 +634,20 @@ func (r Resolver) Rollups(args struct{ Components *[]string }) ([]*RollupResolve
 }
 
 // Releases is the query endpoint to return a list of all releases
-func (r Resolver) Releases(args struct{ Components *[]string }) ([]*ReleaseResolver, error) {
-	components := parseComponents(args.Components)
+func (r Resolver) Releases(args struct {
+	filterArgs
+}) ([]*ReleaseResolver, error) {
+	filter, err := r.filter(args.filterArgs)
+	if err != nil {
+		safe, err := safeError(err, "Invalid filter")
+		log.Printf("Error parsing filter: %v", err)
+		return nil, safe
+	}
 
 	var releaseResolvers []*ReleaseResolver
 	for name := range r.releases {
 		// Get the releases
-		releaseResolver, err := r.getRelease(name, components)
+		releaseResolver, err := r.getRelease(name, filter)
 		if err != nil {
 			safe, underlying := safeError(err, "Unable to retreive release information for %q", name)
 			log.Printf("Error retrieving release information for %q: %v", name, underlying)
@@ -0,0 +1,100 @@
+package api
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/thrasher-redhat/internal-tools/pkg/db"
+)
+
+// defaultSnapshotRunLimit is how many snapshot runs are returned if no limit is given
+const defaultSnapshotRunLimit = 50
+
+// maxSnapshotRunLimit limits how many snapshot runs can be requested at once
+const maxSnapshotRunLimit = 1000
+
+// A single run of a snapshot query
+
+type SnapshotRunResolver struct {
+	run db.SnapshotRun
+}
+
+func (r *SnapshotRunResolver) ID() int32 {
+	return int32(r.run.ID)
+}
+
+func (r *SnapshotRunResolver) QueryName() string {
+	return r.run.QueryName
+}
+
+// Source is the tracker that was queried, ex) bugzilla or jira
+func (r *SnapshotRunResolver) Source() string {
+	return r.run.Source
+}
+
+func (r *SnapshotRunResolver) StartedAt() string {
+	return *formatTime(r.run.StartedAt)
+}
+
+func (r *SnapshotRunResolver) FinishedAt() string {
+	return *formatTime(r.run.FinishedAt)
+}
+
+// FetchSeconds is how long the tracker took to return the bugs
+func (r *SnapshotRunResolver) FetchSeconds() float64 {
+	return r.run.FetchDuration.Seconds()
+}
+
+// BugCount is the number of bugs stored, which is zero if the run failed
+func (r *SnapshotRunResolver) BugCount() int32 {
+	return int32(r.run.BugCount)
+}
+
+// RemovedCount is the number of bugs from an earlier run that day that were replaced
+func (r *SnapshotRunResolver) RemovedCount() int32 {
+	return int32(r.run.Removed)
+}
+
+func (r *SnapshotRunResolver) Succeeded() bool {
+	return r.run.Error == ""
+}
+
+// Error is why the run failed, or null if it succeeded
+func (r *SnapshotRunResolver) Error() *string {
+	if r.run.Error == "" {
+		return nil
+	}
+	return &r.run.Error
+}
+
+// Quarantined is whether the bugs were set aside for review because their counts changed too much
+func (r *SnapshotRunResolver) Quarantined() bool {
+	return r.run.Quarantined
+}
+
+// SnapshotRuns is a graphql query that fetches the most recent snapshot runs, newest first
+func (r *Resolver) SnapshotRuns(args struct {
+	Limit *int32
+}) ([]*SnapshotRunResolver, error) {
+
+	// Parse input
+	limit := defaultSnapshotRunLimit
+	if args.Limit != nil {
+		limit = int(*args.Limit)
+	}
+	if limit < 1 || limit > maxSnapshotRunLimit {
+		return nil, fmt.Errorf("Limit must be between 1 and %d, got %d", maxSnapshotRunLimit, limit)
+	}
+
+	runs, err := r.dbClient.GetSnapshotRuns(limit)
+	if err != nil {
+		log.Printf("Error querying for snapshot runs: %v", err)
+		return nil, fmt.Errorf("Error getting snapshot runs")
+	}
+
+	srrs := make([]*SnapshotRunResolver, len(runs))
+	for i, run := range runs {
+		srrs[i] = &SnapshotRunResolver{run: run}
+	}
+	return srrs, nil
+}
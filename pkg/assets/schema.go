@@ -66,7 +66,7 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
-var _pkgApiSchemaGraphql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa4\x56\x4f\x6f\xe3\xc6\x0f\xbd\xfb\x53\x30\x9b\x43\x6c\x20\xc9\xe5\x77\xfa\x19\xe8\xc1\xd9\xa4\x41\x0e\x9b\x6d\xe3\xa0\x45\xb0\x08\x16\xb4\x44\x4b\x83\x8c\x66\x54\x0e\x65\xc3\x5b\xec\x77\x2f\xe6\x9f\x2c\xc5\x4e\xba\x6d\x4f\x4e\x46\xe4\x23\xe7\xf1\x91\x43\x57\xd4\xd4\x20\xfc\x39\x01\x00\xf8\xa3\x23\xde\xcd\xe1\x57\xff\x33\xf9\x3e\x99\x9c\xc6\x3f\x81\xa9\x65\x72\x64\xc4\x81\xd4\x04\x64\x84\x77\xd0\x5a\xe5\x0f\x94\x11\x1b\x4e\x23\xd2\x44\x76\x2d\x25\xb7\x08\x7a\x0a\x0f\x24\x1d\x9b\xe8\xab\x95\x13\xb0\x6b\x58\x75\x95\x83\xb5\x65\x40\xa8\xd4\x86\x0c\x94\x28\xe4\x04\x9b\x16\xa6\x25\xad\xb1\xd3\x3e\x98\x05\x1d\x8e\xc3\xd7\xd9\x65\xc0\xf3\x9e\xd3\xde\x7a\x0e\x4b\x61\x65\x2a\xf8\x09\x3e\x7c\x8d\xc6\x1f\xce\xa1\xb0\x4d\x6b\x8d\x4f\x78\x0e\x5f\xa2\xc1\xc9\xf3\x6c\x0e\x5f\xae\xba\xea\xf9\xe4\x55\x5a\x08\xce\x60\xeb\x6a\x1b\x12\xf3\x49\x96\x28\xb8\x42\x47\xff\x2e\xc1\x8c\xf6\x7e\x92\xb3\x39\x2c\x93\xe1\x11\x9a\x82\x2b\xa0\x29\x81\xad\xd6\x5d\xeb\x00\x9d\xb3\x85\x42\xa1\x12\xb6\x4a\xea\x3e\x2d\x26\x4d\xe8\x28\x86\x4e\xff\x4c\x0d\x36\x94\x83\x9e\xbc\x4d\xc7\x43\x34\x3f\xe0\x23\x17\x29\xc7\xb6\x1b\xe2\x90\x56\x8b\x4e\xe0\x7f\xe0\x5a\x0e\xb5\x9f\xfe\x1f\xb6\x44\x2f\x2e\x5d\x3c\x99\x4f\xdf\x64\xff\x21\x18\x1c\x29\x40\x0e\x88\x5a\xe7\x3b\x38\x98\xc6\x7b\xee\xef\x7d\x40\xca\x6c\x74\xeb\xf7\x02\x47\x8b\xe7\x13\xaf\xea\xa0\xd0\xab\xae\xea\xf5\xf9\x58\x93\x57\xd5\x37\xa5\x35\xc2\xdd\x75\x04\x55\xe5\x1c\xee\x8c\x9c\x0c\x6c\x7c\x7c\x90\x1a\x05\xa4\x56\xce\xbb\xc0\x16\x1d\x30\x15\x96\x4b\x2a\x61\xfa\xf4\xf4\xf4\x74\xf1\xe9\xd3\xc5\xf5\x75\xca\xec\x40\x01\x43\xb8\x3e\xdb\x8c\x19\xb2\x80\x15\x69\x6b\x2a\xaf\xad\x88\xd1\x9b\x1d\xc3\xf0\x0e\x4e\x50\x3a\x77\x09\x70\x7f\xf3\xfb\x39\x2c\x96\xcb\xbb\xdb\xfb\x9b\xeb\x73\xf8\xe5\xf3\xf2\xf1\x1c\x2c\xc3\xe7\xfb\xaf\xd7\x37\xbf\x25\x71\x06\xe3\x63\x50\xa2\x44\x53\xee\x80\x55\x57\x25\xfb\xae\x69\xd0\x0f\x85\x43\x87\x44\xfb\x81\x32\xb3\x3f\xc0\xbd\xed\x8d\x94\x83\xb3\x8b\x8b\x8b\xb3\x88\x2a\xc8\x15\x49\xaa\xca\x31\x6c\x6a\x50\xe9\x9c\x4c\x4b\xec\xac\x19\xb3\xa4\x42\x47\xa8\xca\x50\xd9\x33\x95\x0f\x1e\xed\x6b\xc8\x05\xb8\xc2\x32\x41\x49\x42\xdc\x28\xef\xb4\x66\xdb\x64\xb4\x33\x07\x2d\x2b\xcb\x4a\x76\x41\x5e\x1b\xe5\xd4\x4a\x69\x25\xbb\x08\xdc\x36\x4b\xef\x3e\x12\xc4\xa2\x17\xed\x0b\xed\xb6\x96\xcb\xc3\x16\x1d\x11\x99\xad\x06\xca\xcc\x50\x77\x3d\xe7\x50\xa3\x03\x6b\xc8\x57\xad\xf1\x09\x17\x9d\x13\xdb\x10\x43\x11\x5a\xe2\x75\x04\x25\x49\x23\xc9\xec\x63\xa0\xf3\xca\x5a\x4d\x68\x86\x7c\x9a\xae\x59\x11\xfb\x6c\x4b\xdc\x39\x70\xca\x14\x34\x56\xf1\x5a\xb1\x13\x10\xc6\xe2\x85\xca\xc4\x67\x95\xaf\x9c\xbb\x26\x36\xf0\xa8\x71\x42\x53\xa4\x42\xc5\xb6\xfc\x7b\xe5\x8b\x15\xd4\x69\xfc\x6b\x1d\xdf\x02\x65\x02\x44\x78\x82\x52\x78\xad\xe7\x70\xc5\x84\x2f\xa5\xdd\x9a\x7f\xe2\x1f\xc9\x59\x69\x5b\xbc\x10\xf7\xcc\xa7\xe7\x23\x9e\xba\xff\x04\xfd\x5e\x89\x44\xb0\xa8\x33\x85\xc3\xc2\x8c\x43\xf6\x83\x28\x1f\x8d\x58\x0d\x69\x0c\xaa\x36\xcc\x23\xce\xfd\x01\x51\xc1\xf8\x60\x5a\x8d\x9c\x41\x95\x2e\x36\x10\x32\x1d\x01\x0a\xb2\xdf\x12\x13\x18\x2b\xf9\x7b\xcb\xb4\x51\xb6\x73\xa1\x98\x67\x6e\x18\xd2\xd0\xf6\x47\x03\x06\xd4\xf7\x10\x43\x6c\x1c\x87\x2e\x3a\x66\x3f\x19\x07\x21\x0b\x6d\x1d\x95\x7b\x41\x9e\xf6\xef\x27\xb4\x6c\x37\xaa\xf4\xe4\x6b\xdd\x3f\xe0\x60\x88\xfc\x54\xf6\xa5\x0c\x90\x5a\x91\x91\x33\xe7\x3b\xdf\x08\xb4\x58\xd1\x65\x2c\x41\x8f\x73\xa0\xeb\x7e\xe4\xf4\x1b\x82\x8a\xda\x18\x8d\x7a\x80\x5b\x32\xc4\xa8\xf5\x2e\xee\x38\xfb\x95\x20\xdf\x27\x6f\x14\x3f\xf0\x2c\x0c\xdf\xc2\x7e\x4b\xda\x97\xcb\xfb\xee\x17\xa1\xb4\xd2\xf4\x33\x29\x76\xa0\xdf\x4b\x42\x27\xc3\x06\x39\xf0\xed\x2b\x32\x90\xf6\x31\xb8\xe8\x3a\x4f\x3d\x9e\x28\x5e\xf4\x03\xbc\xe7\xd8\xfb\xfa\xfd\x22\x94\x2d\x3d\xc9\x07\xbb\x49\xf2\x3a\x07\x74\xb0\x25\xad\xfd\x6f\xde\x26\x7c\x02\x84\x45\xed\x47\x51\xe6\x27\x47\xf1\xdf\x2a\xc6\xb6\xf6\xdb\x52\xdb\x71\x6b\x1d\xb9\x54\xa6\xf4\x5c\xa4\x2a\x8d\x56\x9c\x01\x7d\x71\x8e\x79\xe8\x3c\x94\x86\x0b\x92\x13\xe4\xa3\x0f\x69\xaa\x20\x2c\x36\xa8\x34\xc6\xe1\x3f\x20\xa7\xc2\x63\x4e\xa1\xc2\xb8\x16\x62\xd8\xd6\xaa\xa8\xc1\x58\xdf\x19\xb0\x26\x94\x8e\x3d\x31\x4c\x80\x65\x99\xa7\x41\x3a\xff\x68\x9b\x56\x93\x1c\x4d\xff\x2d\xc8\xc2\x96\xe1\x11\xc5\xa2\xa0\x56\xfa\xf1\x62\x4b\xfa\x99\x89\xbe\x1d\x60\x3d\x1c\x23\x7b\x45\xb2\x25\x32\x91\x85\x7e\x8d\x05\x42\xd6\x8a\x9c\xcc\x42\x49\x6f\x17\xfb\x2f\x51\xc9\xb3\xe1\x76\x37\x5c\xe3\xbe\x4f\xfe\x0a\x00\x00\xff\xff\x35\x94\xe9\xfb\x3f\x0c\x00\x00")
+var _pkgApiSchemaGraphql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xdc\x3b\x5d\x6f\x1c\x39\x72\xef\xfe\x15\xa5\xdd\x07\x8f\x92\xb1\x4e\x1b\xe3\x82\xdc\x00\x41\x20\xf9\x63\x57\x41\xce\xbe\x48\xba\x1c\xf6\x0c\xc3\xe0\x74\xd7\xf4\xf0\xc4\x26\xfb\x48\xb6\x66\xe7\x8c\xfd\xef\x41\x15\x3f\x9a\xdd\xd3\xa3\x8f\xdd\xa7\xdb\x27\x7b\xd8\xac\x2a\xb2\x58\xdf\x55\x72\xd5\x16\x5b\x01\x5f\x5f\x00\x00\xfc\xbd\x47\xbb\x5f\xc1\xff\xd2\x3f\x2f\x7e\x7e\xf1\xe2\xdb\xf0\x5f\xb0\xd8\x59\x74\xa8\xbd\x03\xbf\x45\x40\xed\xed\x1e\x3a\x23\x69\x41\x6a\x6f\x78\x35\x60\x7a\xe1\xf7\x1d\x46\xb0\x80\xf4\x5b\xb8\x46\xdf\x5b\x1d\x60\x95\x74\x1e\xcc\x06\xd6\x7d\xe3\x60\x63\x2c\x08\x68\xe4\x3d\x6a\xa8\x85\x47\xe7\x45\xdb\xc1\xa2\xc6\x8d\xe8\x15\x11\x33\xa0\x78\x99\xbf\x9e\x9e\x45\x7c\xb7\x5b\x2c\xb6\xb7\x62\x0f\x52\x3b\x8f\xa2\x86\x35\x82\x00\x2f\x5b\x84\xc5\xf5\xfb\x37\xaf\x5f\xbf\xfe\xc3\x12\xf0\xa7\x15\xfc\xdb\xf9\x77\xff\xf1\xea\xfc\xdf\x5f\x9d\x7f\x77\xfb\xdd\xef\x57\xe7\xe7\xab\xf3\xf3\xbf\x9e\x12\xfa\x06\x3d\x1f\x6b\x6b\x7a\xab\xf6\xe0\xb4\xe8\xdc\xd6\x78\xf0\xe2\x0e\x35\x08\x0f\xc6\xc2\x1a\x37\xc6\x22\x48\xda\x29\x3c\xd4\x62\x5f\x1e\x84\x79\xf6\x41\xb4\x08\xc2\x36\x7d\x8b\xda\x83\x92\xad\x8c\xac\xb2\xe8\xd2\x4d\x04\x38\xa9\x1b\x85\x03\x11\x06\x1d\x5f\x57\x28\xc5\xaf\x20\xd1\x8d\xae\xeb\xf0\x1e\xad\xf4\x12\xdd\x12\x3a\x2b\x4d\xfa\xbf\xd0\x35\x6c\x94\x68\x5c\xa6\xee\xa0\x15\xbe\xda\x06\x06\xef\xa4\xdf\x82\xd0\x7b\xe2\x38\x5d\x33\xb0\xfa\x5e\xa8\x1e\x5d\x89\xbe\xea\x9d\x37\xed\x7b\x89\xaa\x1e\x30\x05\x44\xe8\x02\x2a\xa3\x61\x13\xbe\x33\x17\x84\x45\xfd\xd2\x83\xd4\xc5\xdb\x2f\xc1\xf5\xd5\x16\x84\x83\x6a\xf3\xe5\x5f\xe2\xf6\x92\x0c\xfe\xe4\xd1\x6a\xa1\x2e\xfb\xe6\x18\x19\x25\xf5\x1d\xd6\xcc\x8a\xe9\xb1\x13\x34\xc8\xda\xd1\xb3\x9e\x82\x80\x4a\x38\x04\xdd\xb7\x6b\xb4\x73\x84\x6e\xad\xa8\xee\xd0\xfe\x02\x62\x3e\x41\xae\xf7\xd0\xa2\xd0\x52\x37\x81\x66\x60\x15\xda\x2f\x9d\xb1\x5e\x28\x12\x90\xbf\x49\x2b\x4a\xea\xce\xf4\xb6\xc2\x63\x44\x37\xd6\xb4\xc7\xe9\x05\x22\xeb\xbe\xf9\x87\x54\x4a\x8c\xb1\xd3\x3b\x2c\xb2\xe0\xaf\xe0\xc6\x5b\xa9\x1b\xf8\x4f\xf8\xe6\x4b\x50\x93\x6f\x96\x50\x99\xb6\x33\x9a\xe4\x60\x05\x9f\xc2\x86\x93\xcf\xcb\x41\x4a\x13\xd4\xb2\x10\xa9\xd1\xce\x41\xba\x46\xcb\x2c\x64\xa3\x95\x52\x66\x56\xf0\xe9\xcd\xf0\xf3\xbd\x54\x1e\x2d\x91\x4d\xef\x70\xd9\x4f\x80\xa7\x0f\x34\xfa\x18\xf9\x57\xac\x9d\xae\xe0\xd3\x65\xdf\x7c\x3e\x99\xb3\x28\x7c\xf5\xc0\x5b\x16\x4d\x6d\xd6\xa6\xde\xc3\x96\xe4\x70\x2b\x74\x83\x35\xb1\xb1\x32\x2d\xbd\x05\xfd\xd0\x49\x6c\x03\xeb\x83\xf8\xd0\x73\xd4\x62\x1f\xd5\xc2\x79\xa1\x90\x4e\xbd\xa0\xb5\x15\x5c\x69\x7f\x72\x84\xb9\x0f\x9d\xcd\x5b\xc4\x6c\xed\xf8\x70\x03\xd9\x75\xdf\x40\x8d\x1d\xea\x3a\x28\x17\x89\x45\x71\x9f\x64\x25\x4a\xc1\xaa\xb1\xf3\x5b\x90\x0e\xb6\x66\x07\x2d\xc9\x90\xc2\x7b\x54\x8e\x48\x04\x54\xa8\x2b\x89\x6c\x38\x37\x46\x29\xb3\x1b\x5b\x97\xd7\x4b\x32\x69\xad\x71\x1e\xbe\x3b\x8f\xf6\x25\xc3\xed\x6f\x2d\xe2\x42\xd6\xe9\xb2\x4c\x8c\x7f\x9c\xae\xe0\x6d\xde\xf5\xc1\xd4\x38\x73\xd3\x4a\xd8\x70\x0f\x01\xde\xa2\x52\x06\xd6\x46\xd8\xfa\x29\x26\x9e\x2e\xcd\x9b\x5f\xba\xe9\xe5\x47\x36\x90\xf7\xd0\xed\x09\x40\x8b\x36\xab\x8e\x01\x39\x58\xa2\x08\x09\x95\xd1\x1b\xd9\xf4\x56\x78\x69\x74\x40\xc3\x67\x5c\x30\x9a\xa4\x06\x27\xcb\xe1\x54\x69\x8d\x1e\xf4\x8d\xb0\xf5\xec\x8b\x12\x0e\xa8\x4c\x4f\xae\x8f\xee\x86\xa2\xda\x66\xa7\x36\xb9\xbb\xd1\x40\x3a\x56\xb8\x15\xa2\x05\x6b\xf4\x3b\x44\x0d\xce\x0b\xeb\xd9\x82\xa3\xae\xcb\x8b\xa2\xae\xe1\x89\x0c\x62\x70\xfa\x1e\x90\x79\x03\x7f\x80\x1d\xe2\x9d\x83\xc5\x6b\x70\x9d\x25\x17\x7d\x5a\x78\xb0\xc5\x37\x5f\x50\x58\x25\xd1\xf9\x6f\xf8\x6d\x08\x76\x23\xed\x21\xcf\x99\xe0\x7b\x65\x76\x07\x0c\x63\x52\xe9\xf7\x12\x50\xe7\x8f\xc4\xb9\xcb\x04\x77\x32\xcb\xbf\x44\xc5\x63\x0d\xd2\xb9\x9e\xcc\xa4\xae\xa1\xeb\x95\x02\x8b\x7f\xef\xd1\x79\x16\x67\x0a\x0b\xfc\xb6\x5f\x53\xec\x61\x9c\xf4\xc6\xee\x61\x61\x76\x1a\xed\xef\xe8\xe9\x4f\x9f\x2a\x58\x03\xf8\x03\xd2\x75\x75\xec\x20\xd1\x41\xb0\xc9\xce\x36\x59\x50\x30\xa0\x2b\xd5\xd7\x58\x83\x50\x46\x37\xc1\xcf\x12\x39\xd3\xa1\x06\xa3\x93\x77\x0d\x77\x08\xe8\x17\x74\x94\xc7\xe4\xee\x7b\xe9\x7f\x88\x00\xf3\xfc\x63\xe5\xb5\x58\x51\x9c\x61\x7b\xed\x92\x17\x49\x77\x4a\xd1\xc3\x12\x34\xee\xe8\xb2\xfc\xb8\xcb\x78\x60\x72\x17\x1b\x21\x15\xd6\x0c\x5c\x0a\x1d\x07\x2d\x23\xb1\xfb\xfd\x39\x2c\x06\x73\x71\x9e\x0c\x46\xa2\x74\xdd\x6b\xb7\x60\xa8\x64\x24\x3e\xdd\x0c\x9f\x0e\x4f\x2f\x32\x64\x3a\x73\x2d\xbc\x58\x93\x03\x7f\xc2\x5b\xfe\xda\x38\xf0\xf9\xd1\x5e\x30\xf7\xc2\x86\x00\x81\xce\xdb\x59\xbc\x97\xa6\x77\x43\x08\x98\x30\x3c\xe6\x95\xff\xf9\xdd\x6f\x7a\xda\xc9\xa3\xc6\x67\x8c\xca\x63\x8d\x52\x7d\xe7\x40\x38\x67\x2a\x29\x48\xc7\x59\x33\xd2\xdb\x5a\x54\x28\x1c\x06\xde\xc5\x1f\x0b\x5d\xb0\xe5\xe4\x37\x1c\xc0\x5c\x87\xfb\x4e\x18\x28\xb2\xf3\x48\xcc\x33\xf7\x68\x59\x4a\x3b\xe1\x3c\x64\x2b\x0e\x8b\x68\xd9\xa3\xf8\xc7\xed\x8b\xdf\x2c\xbf\x3e\x5d\xf3\x0d\x3f\x9f\x1c\xe5\x18\x25\x4b\x51\x8a\x1c\x2c\x82\xa4\x0d\x92\x77\x20\x96\x89\x71\x11\xe2\xb7\xcc\xb9\x70\xc5\xcf\x27\x21\x89\xbf\xd0\x7b\xf8\x9b\x33\x31\xf1\x3b\x7b\xe1\x2a\xa1\x84\x85\xff\xbe\xf9\xf8\x81\x3e\x1f\x1c\x67\x9c\xaf\xec\xb6\x68\x31\xc7\x5c\xf5\x90\x9b\x70\x7e\xc7\x61\xf6\xd1\x0c\xf3\x5b\x78\xcf\xa6\x3d\x6e\x35\x8a\x3d\x50\x90\x79\x4a\x5e\xf7\x80\x0a\x63\x8a\xb4\x0f\x64\xcf\x5e\x48\xdd\xf5\x7e\xe6\x54\xa9\x9c\x40\x66\x7f\x72\x08\xb2\x21\x67\x00\xef\x7e\x3a\xa5\xd4\xb3\x6b\xbf\xb8\xca\xd8\x10\xa6\x8e\xcc\x4b\x81\x81\x79\xe1\xc8\xb4\x47\xb2\xf4\x29\x2c\x16\xdc\x24\x06\x72\x45\xe3\xb2\x6f\xe6\x0f\x70\xf5\x36\x88\x55\x0a\x9c\x8b\x3d\x24\x81\x29\xea\x97\x9c\x46\xc3\x4e\x38\xb0\x58\x19\x4b\xb1\xc3\xe2\xc7\x1f\x7f\xfc\xf1\xd5\x1f\xff\xf8\xea\xed\xdb\x28\x9b\x07\x6e\xa4\x44\x37\xc8\x6b\x42\xca\x7c\x80\x35\x52\x0c\x42\x57\x39\x03\xf8\xb3\xeb\x85\x52\x7b\x7a\x0b\x0a\x53\x28\x24\x57\x7b\x8a\x48\x62\x08\x9c\x70\x14\x77\x2c\x69\x70\xb4\x30\xec\x3a\x4a\x88\x41\x3a\x2b\x5b\x61\xf7\x6f\x06\x9c\x87\x87\x26\x38\xe7\x85\xef\xdd\x19\xc0\x87\x77\x7f\x59\xc2\xc5\xcd\xcd\xd5\xf7\x1f\xde\xbd\x5d\xc2\x9f\x3e\xde\xdc\x2e\x29\x37\xfb\xf8\xe1\xcb\xdb\x77\xff\x17\x5d\x2a\x6f\x9e\x43\xe5\xa5\x57\x98\xe4\x6c\xdd\x37\x71\x7f\xdf\xd2\x21\xe6\x00\xb2\x6d\x98\xfa\xa3\x84\x00\xe0\x83\x49\xbb\x88\x57\x2f\x5f\xbd\x7a\xf5\xf2\x71\x26\x7a\x61\x1b\xf4\x51\xc9\x1e\x64\x64\xc2\x7d\xec\x00\x25\x1b\x6f\xc7\x58\x0f\xaf\x83\xad\x90\x2a\xdd\xbf\x43\x4b\x1a\x3d\x7a\x1f\xc9\x9e\x57\x36\x9a\x43\x96\x70\xd6\xb4\x70\x6b\xa6\x28\x2f\x80\x95\x04\x6a\xf4\x68\x5b\xa9\x53\x9c\x1b\xb1\xbd\x74\xc9\xbe\xed\xd9\xb7\xdf\x4b\x27\xd7\x52\x49\x1f\x43\x9f\xae\xbd\x21\xf0\x91\xd0\x5f\x64\xd3\x7c\x87\xfb\x9d\xb1\xf5\x71\xd6\x33\x44\xda\x35\xc3\xc3\xab\xfc\xcc\x6c\x61\x8c\x46\x12\x94\x96\x0e\x9c\x2a\x30\x50\xcd\x3e\xae\x8c\x29\x73\xda\xf6\x86\x1f\xe9\xd2\x18\x85\x42\x4f\x44\x93\x72\x02\xa9\xef\x48\xa4\x53\x36\x22\x35\x18\x52\x9e\x5c\x91\x09\xd8\x26\xe6\xf9\xdd\xf0\x73\xfc\xee\xe3\x62\x02\x95\xfc\x2a\x1c\xab\x7f\x50\xb1\x80\x3d\xa6\x7d\xa2\x19\xf3\x31\x2b\x4e\xf0\x3b\xfb\x68\xda\x7a\xdb\xa0\xf6\x4b\xd8\xca\x66\xbb\x84\x16\x6b\xd9\xb7\x4b\x50\x66\xb7\x84\x5e\xbb\x0e\x2b\xb9\x91\x09\x65\x74\x59\xb3\x7a\x41\xa8\xd3\xdb\xfe\x02\xd4\x09\xf4\x59\x32\xba\xe1\xa4\x63\xf4\xfa\x94\x0f\x59\x8f\x76\x8a\xe7\x2f\x5b\xd4\xf9\xf1\xc9\x60\x06\xd8\x1c\xc9\x93\x21\xa1\x74\x51\x6e\xa0\xd7\x77\xda\xec\x52\x7a\x6f\x91\x93\xfd\x5b\x39\x58\xfc\x63\x28\x15\xc5\x56\xa9\x3a\xf4\x28\x66\xda\xfd\x86\x37\xcf\xe0\xa6\x6b\x5b\x74\x46\xf5\x44\x9c\x5e\x5e\x40\xa5\x8c\xc3\x3a\xda\x98\x77\x6d\xe7\xf7\x9c\xaf\x72\x76\x48\xb5\xa0\xc4\x80\x04\x35\x65\x01\xe1\x0c\x56\x13\x76\x5b\xe9\x91\x53\xf0\x00\x34\xfc\x9e\x03\x0a\x32\x1d\x2b\xc2\x8e\x3d\x36\x6b\x6f\x36\xc1\xe4\x22\xd7\xca\x90\x64\xff\x2b\xe5\x88\x58\x4b\xbd\x31\xff\xc5\xa8\xa7\x81\x4b\x42\x4c\xf1\x43\xf0\xe0\xa5\x81\x08\xee\x8f\xe2\x8a\x32\xbb\x0c\x5a\x07\x0b\xaa\x00\x9f\x4e\x4a\xc0\x89\xb7\xf4\x10\xfc\x85\xf4\x81\xaa\xc8\x95\xe8\x7c\x4f\x89\xd6\x7a\x7f\x98\xcc\x46\x7b\xc3\x00\xe3\x64\xe1\x74\x15\x62\x98\xe1\xfa\xb2\xce\x09\xf1\x50\x72\x6b\x7b\xe7\xa9\x33\xb0\x91\x3f\x11\x89\x50\xcd\x67\x95\xcc\x16\x3d\xd6\xe1\x3e\xea\x15\x7c\x22\x7b\xf6\xf9\xe4\x31\xa4\x54\x04\xd8\x09\xe9\xe9\xce\x46\x4f\xd0\x31\x83\xdd\x3c\xae\x02\x55\xb2\xde\xd2\x91\x8f\x11\x50\xf7\x9d\x92\x15\x05\x0d\x66\x53\x88\xa2\x36\xbe\xfc\x18\x4f\xdc\x77\xf8\x71\xc3\x56\xe3\x09\xe8\x49\xe4\x2b\x65\x92\x8d\x3f\x40\xce\xdf\xa2\x16\xd1\x7f\x0f\x31\x0f\x76\x2d\x16\x52\x5d\xf9\xea\x74\xa1\x1a\x5d\x65\x65\x57\x94\xdb\xc2\xc6\x37\x54\x2c\x1b\x99\xb7\xac\x8d\x31\xa9\x8f\x18\x49\x1a\xa0\x15\x75\x91\xb4\x3f\xa8\x90\x01\xea\x88\x46\x1e\x35\x44\x4c\xe0\x90\xf8\x01\xde\x43\xbb\x44\x6c\x08\x26\x23\x1e\x33\x96\x05\xd6\x7d\xb3\x04\xa3\xea\x5c\x6d\xc9\x3a\xdf\x6b\x2f\xd5\x58\xa2\xc9\x99\x6d\xd0\x57\xdb\xc1\x1a\xbe\x74\xb0\x95\x8e\x4a\x54\x14\x12\x53\x49\x69\x27\xf6\x0e\x70\x30\x1b\xec\x8f\x62\xa8\x1f\x0e\x1a\x01\x28\x0d\xe3\x13\x8d\xa5\x2c\x56\x21\xa9\x56\x19\xc5\xd5\xe2\x06\x2d\x8e\x7c\x91\x89\x05\x53\x32\x11\x14\x77\x9e\x01\x5c\x3c\x81\x32\x23\x8d\x15\xd2\x29\x55\x72\x69\x36\x5d\x0b\x2a\xc2\x4c\xe2\xb6\x3a\xd2\xcf\x08\x78\xe7\xd8\x4c\x6a\x9e\x1e\x6f\x6c\x0c\xa2\x37\x31\xbd\x9e\x78\x93\x83\xb4\x2d\x25\x3f\x25\x37\xce\x42\x7b\x92\xaa\xbb\xf0\xf5\x90\x61\x65\x2c\x7f\x78\xa8\x49\x38\x4f\xf8\x7e\x55\x3c\x3f\x8a\x67\xab\x6c\xe0\x8f\x25\x2c\x25\x4f\x38\xcc\x8a\x47\x89\x35\x69\x32\x22\x49\x3b\xa4\x9b\x8d\xc5\xa9\x9a\x99\x6a\x59\x03\xbd\xde\xaa\x63\xe4\xb2\xdd\xab\x52\xf1\x79\x8d\x8a\x02\xf9\x3f\x6b\xfa\x5c\xc7\x05\xe8\x1d\xc9\x15\x4a\xea\xb2\x28\x13\x3b\x71\xe1\xdb\x8c\x3b\x21\xec\xbd\x43\x3b\x47\xa1\x45\x32\x33\x51\xd4\xe2\x8f\x23\x28\x22\x44\xdd\xc7\x97\x99\x33\x1a\x39\x80\xd7\xd9\xbe\xd5\x7d\xe6\xee\xd4\x1c\xe5\x17\x7d\x7e\x74\x70\x51\x79\x79\x5f\xc4\x44\xe3\x28\x96\x11\x93\xe6\xaf\xa9\xee\x2f\x6c\xb5\x95\xf7\x29\x9a\x0a\xa1\xc2\x6c\x80\x7a\xf5\x36\xb3\x27\x2b\x10\x7b\xa0\xac\xcd\x75\x6a\x7b\x44\x66\xb0\x48\x2d\x4b\x53\x4c\x51\xb3\xf0\x5e\x54\x5b\xb2\x72\x91\xb1\xeb\xbe\xb9\xaa\x67\xfd\x53\x81\x79\x68\x56\x8e\xb4\x70\xc6\x6c\x5c\x66\xaf\xa8\xcd\x54\x5d\x83\xa6\x92\xb7\x54\xb8\xf1\x60\xfa\x68\x69\x09\x3b\x75\x0b\x42\xfc\xcc\x9a\x4a\xf4\x37\xd4\xb3\x32\x9b\x6c\xba\xac\xe9\x9b\x6d\x16\xf9\xd8\x19\x98\x36\x59\x72\x67\x9d\xcf\x13\xf3\xf5\xd4\x86\x18\xe9\x39\x6d\x48\x2c\xcd\x07\x7d\x76\x1a\x3e\xd7\xfc\x89\xe1\xe5\x10\xa7\xd1\x32\xb1\xf8\x7f\xa4\xf3\xa9\x1d\x92\xaf\x19\xae\x27\x8b\xa3\xc7\x3b\x11\xd4\x41\xdd\x39\xf5\x7e\x72\xfd\x39\x1d\x3d\x9a\xb3\x44\x02\xbe\x3e\x60\x2e\x86\x53\xcd\x5d\xaa\x70\xef\xe9\x68\x09\x10\x16\x3b\x63\xef\x68\xa5\xb3\xa6\xb1\xe8\xd2\x64\x82\x37\x5e\xa8\x83\xbc\x65\x8a\x89\xad\x54\x6b\xee\xb1\x1e\x46\x45\x08\x2d\x27\xfe\x3b\x52\x4f\x51\xd7\x93\xbb\x2e\x73\xde\x84\x73\x97\x26\xe2\xec\xa1\xb1\x7e\x0e\x79\xd3\xfb\x92\x21\x7c\x00\xb3\xd9\x3c\x8b\x2c\x09\x71\xa4\x19\xbd\x4b\x6c\x53\x71\xf6\x48\x18\xcb\x06\x52\x7c\x9f\xa2\xa9\x33\x7a\x22\x6e\x67\xb1\xcb\x2f\x1f\x6b\xe8\x58\xc5\x70\x9d\xb2\x06\xb7\x95\x1b\xff\x3b\x63\x65\x23\x75\x4e\x9e\xc6\x0f\x19\x18\xff\x58\x11\x2a\x9c\xf3\x97\xb9\x2d\xb6\x2e\xe3\xa5\x6f\x63\xc7\xcb\x46\x3b\x46\x41\x04\xb4\x68\x1b\x1c\xb7\xd2\x28\xc0\x8d\x3b\x72\x95\x67\xe2\xe5\x0e\xfc\x10\xc1\x5f\x07\xf0\x03\xf3\x18\x0d\x6b\xb8\x0d\x07\xcf\x23\x6a\x7c\xdf\x6c\x70\xc3\x79\x92\x4f\xb1\xcd\x8c\xb9\xcd\x3e\x60\x84\x86\xd8\x14\x2f\x33\xe7\x05\xa4\x8f\x09\x4c\x89\xfa\x22\xeb\xd7\xe3\x5e\x70\x14\x01\xb4\x52\xa1\xf3\xe4\xa7\x52\xf8\x38\xef\xc0\xf2\xbe\x59\x17\x6f\x1a\x39\x34\x05\x63\xf1\x07\xdd\xa8\x16\x84\xc7\x8e\xc3\xc0\x09\x96\x3c\x74\xd0\x1e\x7a\xe1\x18\xad\x32\xbf\x23\xb2\xde\x6f\xcd\xf1\xdc\x7d\x90\xb3\x08\x3e\x30\x70\x48\xd6\x0f\xb8\x35\x8b\xe0\x88\x37\x66\x80\xbe\xab\x9f\x8a\x26\xe6\xe4\x47\x1e\x92\xb2\xc1\x0e\xa3\x47\x0f\x5b\x0b\xa4\x51\xd7\xa3\xad\xb6\x7d\x4c\xf3\xa7\x79\x2a\x6b\x7b\xd1\x04\x85\xaf\xc7\x4a\xc3\xa5\xc2\x4f\xb1\x1c\x89\x66\xe7\x02\x6d\xc1\x12\x18\x7b\xbe\x75\x4a\xef\x9f\x11\x6c\x67\x3e\xd1\x9d\xb8\xb1\x7f\xc8\xe3\xb8\x7c\xe1\x1f\x04\xde\x48\x2d\x1d\x65\x35\xc6\xa6\x16\xf3\x04\x4f\xda\x71\x88\xe8\x07\xb3\x03\x2a\x2d\x83\x2f\x6f\x67\x0c\x47\xab\x96\xfb\x3e\x29\x0a\xe2\xb4\x13\x1c\x56\x46\xd7\x51\xac\x39\x9d\xba\x09\x2b\x2b\x78\xaf\x8c\x38\xe2\x11\x28\xe6\x00\xca\x9b\x98\x55\x7f\x45\x6b\xa2\x8a\x85\x0b\xf0\xa1\x73\x74\x72\x98\xb6\xce\x20\x8b\xb3\x5c\x10\x66\x2a\x2c\xe3\x49\x43\x82\xf1\x79\xc8\xc3\x59\xec\x94\xa8\x12\x72\x8b\xec\x8f\x0e\x09\x44\xa3\xc6\x98\xd9\x33\xc6\xb3\xc6\x3a\x77\x55\x21\xd6\xb3\xb6\x6b\x3f\xbd\xc4\x48\xb4\x33\x64\xf4\x9c\xd6\x0e\x7a\x7b\x40\x39\x85\x37\x49\xdf\xbc\x31\xd0\xf2\x54\x9f\xae\xa7\xa7\x43\x0f\xc2\xc9\x3a\xf4\xef\xc9\x5f\xe2\x2e\x77\xe0\xcd\x26\x71\x3a\xca\xb3\xb0\x42\x7b\xa9\x47\xe7\x8f\x6a\x45\x64\x39\x16\x1a\x66\x91\x28\x23\x4b\xa1\xdc\x78\xf6\x68\xe4\x41\x9f\xd2\x85\x21\xec\x07\x61\x6c\xa1\xfc\xc7\x62\xd6\x78\xd9\x2c\x10\x2b\xb8\xec\x9b\x31\xde\x18\x5e\xe4\x94\x3a\x96\x91\xc0\xe8\x6c\xc3\x63\x8a\xc6\x36\x8c\x87\xb5\x38\xec\x20\x86\x89\xa1\x46\x23\x94\x45\x51\xef\x41\x74\x1d\x0a\x9b\xa3\xaf\xc0\x83\x69\x7d\x6a\xcc\x8e\x1c\x56\x52\xed\x5d\xdf\xc5\xe9\xc2\x80\x9a\xc6\x1a\xa3\x01\x24\xf6\xea\x51\x5d\x3b\x32\xb7\x28\x64\xcf\x73\x76\x28\x29\xa5\x12\xf8\x80\x81\x8e\x16\x7f\x5c\xcd\x32\x3e\xa0\x18\xa5\x23\x47\x70\x05\xd3\x75\x8d\x35\xfc\x20\x52\xf3\x0f\x2d\xfc\x89\x87\x2c\x4b\x42\xc7\x8c\x62\x9a\xf7\xc2\x3a\xcd\x6b\x26\x5a\x63\x12\x93\xf9\xcd\x65\x9c\xd1\x59\xb2\xa9\x5c\x02\x5a\x2b\xbc\x18\x89\x87\x2f\x70\x8f\xee\x3c\x51\xa2\x71\x01\x2e\xf3\xfd\x38\xeb\xd2\xea\x55\xfd\x40\xfd\xf7\xe9\xe8\x8e\x77\xce\x52\xc5\xfe\x19\x67\x7b\xa8\xc8\x1f\xa7\xa2\x1e\x8a\x7c\x69\x08\x38\x84\x47\x59\xb9\xe2\xe8\xe5\xc2\xd8\x72\x88\x49\xed\x63\x45\xf6\x34\x29\x1c\xe5\xf4\xb9\xcc\x39\x2e\x1a\xe7\xa3\x92\x78\xd7\x06\xb9\x78\xcc\x73\xe8\x34\xbc\x13\xcf\x63\xec\xc4\xf1\x6b\x6a\x85\x94\xc7\x28\x67\xb3\x56\x65\x74\x3e\xf1\xf3\xc1\x06\xb2\x1e\xc5\x7a\x35\x7b\x7d\x36\x0a\xac\x3c\xa1\x27\x00\x5f\xa7\xfe\x30\x02\xce\xd4\x33\x63\x09\x1f\xf5\xb3\x9a\x27\xb9\x66\x19\x10\xa7\x46\xc0\x41\x4b\xef\xe1\xce\x78\x90\x8f\xa1\x98\xfe\x40\x57\x3c\xba\xa8\xc1\x6e\xf2\xf5\xcf\x80\xdb\xf9\x94\x3c\xc5\xca\xfe\x32\xb4\x97\x69\x47\x02\x29\x87\xcb\xe3\xda\x03\x84\x46\xb9\xdf\xe3\x44\xc2\xf6\x92\x04\xaf\x0c\x04\x52\xbb\x3e\xcc\x8e\x1c\xcd\xfd\xc3\x44\x08\x9b\x62\x42\xcb\x7f\x31\xc0\x36\x59\x4f\x67\xc3\x1e\xaf\x07\x70\x1a\x1c\xff\x96\x41\x29\x92\xe0\x6c\xc0\x8b\x80\x4e\x28\xb5\x82\x4b\x8b\xe2\xae\x36\x3b\xfd\x1c\xf8\xd0\x37\x8d\x3d\x9b\xdc\x32\x8d\x6e\x29\xac\xba\x5f\x85\xfa\xa1\xde\x2a\x57\x8e\x92\xc6\xa4\x8f\xd4\x51\x1d\x93\xcc\x53\x12\x69\x69\xc4\x79\xe6\xd0\x34\x74\x8a\xe7\x08\x83\x61\x05\xa3\x1e\xab\x2a\x90\xea\xcb\x94\xd8\x53\x5d\xe9\x10\x11\xa7\xd4\x1c\xa2\x50\xeb\x44\xea\x71\x42\x4f\x09\xc3\x4b\x57\x92\xd4\xb8\x7b\x2a\xc1\x1d\x0e\x14\x67\x31\x32\x6d\x31\x26\x5d\xf5\xd6\x52\x7f\xa3\x20\x99\xaa\x7e\x43\x25\x21\xa5\x0d\xd0\x59\x73\x2f\x6b\x62\xbe\x52\x79\x4c\x92\x3b\x75\xd4\xbd\x89\x12\x5b\x29\x89\xda\xbf\xe4\xbf\x26\xd0\x1e\x3a\xd1\xe0\x24\xfd\x80\xaf\x47\x8a\x00\x45\xc2\x21\x83\x6c\x94\x05\x80\xb1\x4e\x64\xc3\x75\x44\x3b\xce\x00\xbe\x47\x8d\x36\x4d\x4b\xa4\xa6\x0a\xf1\x38\xdd\x3e\x4d\x79\x3e\x58\x5a\x48\x8e\x65\x18\xec\xca\x7f\x20\x34\x3c\xee\xe0\x16\x86\xea\xe1\xe7\x08\x7e\x11\xa7\xbc\xc8\x5a\xb3\x23\x83\x7b\x61\xf9\x75\x48\x60\x0a\x45\x98\x43\x17\x40\x57\xd1\x6a\xe4\x80\x2a\x4d\x6f\xe4\x17\xc9\x83\xe7\x42\xe7\xf9\xb2\xc9\xf4\x81\x48\xf3\x24\x4b\x72\x81\x3b\x54\x8a\xfe\x8d\x13\x68\x43\xd1\xb0\xe6\x3f\x5a\x8a\x86\x33\x50\xa1\x6f\x8d\x15\xdd\x96\x1a\x6a\x5d\x6f\x3b\xe3\xc8\xc8\xf1\xa3\xc6\xa9\x10\xf8\xfa\x60\x87\x80\x9b\x4f\x9c\x7a\x24\x33\x57\xce\x5b\x8e\xc6\xb6\x4b\xb0\xf8\x82\x70\x71\x2f\xa4\x12\x61\xc6\xa3\x60\x4e\x23\xe6\x80\xe8\x3b\x88\x0d\x4d\x8b\xed\xb6\xb2\xda\x82\x36\xa0\x71\x07\x1b\x14\xd4\xca\xa5\x3f\x7e\x89\xe6\x3a\x25\x69\xbc\x4e\xb3\x42\x0a\x3d\x3e\x07\x65\x65\x6a\x0a\x59\x40\x54\x15\x76\xd9\x7d\xd3\xea\x7b\x8b\xf8\x8f\x03\x5c\xd7\x73\xcc\x1e\x0f\xdd\xa7\xd1\xe2\x98\xb8\x39\x7f\xca\x7a\xfb\xfd\xc5\xf0\x25\x48\xf2\x69\x39\x6b\x59\xce\x24\xfe\xfc\xe2\xff\x07\x00\xf6\xaa\x07\x0f\x3a\x37\x00\x00")
 
 func pkgApiSchemaGraphqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -81,7 +81,7 @@ func pkgApiSchemaGraphql() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "pkg/api/schema.graphql", size: 3135, mode: os.FileMode(436), modTime: time.Unix(1526666473, 0)}
+	info := bindataFileInfo{name: "pkg/api/schema.graphql", size: 14138, mode: os.FileMode(436), modTime: time.Unix(1792203922, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
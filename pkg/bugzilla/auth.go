@@ -0,0 +1,129 @@
+package bugzilla
+
+import (
+	"net/http"
+	"net/url"
+)
+
+// AuthMethod is how the client authenticates with bugzilla
+type AuthMethod string
+
+const (
+	// AuthAPIKey sends a bugzilla api key with every request
+	AuthAPIKey AuthMethod = "api_key"
+	// AuthToken logs in once with the username and password and sends the returned token afterwards
+	AuthToken AuthMethod = "token"
+	// AuthPassword sends the username and password with every request
+	AuthPassword AuthMethod = "password"
+)
+
+// Credentials holds what the client needs to authenticate with bugzilla
+type Credentials struct {
+	// Method defaults to AuthAPIKey if an APIKey is given, otherwise AuthPassword
+	Method AuthMethod
+	APIKey string
+	// Header sends the api key or token as an X-BUGZILLA-* header instead of a parameter
+	Header   bool
+	Username string
+	Password string
+}
+
+// method returns the auth method to use for the credentials
+func (c Credentials) method() AuthMethod {
+	if c.Method != "" {
+		return c.Method
+	}
+	if c.APIKey != "" {
+		return AuthAPIKey
+	}
+	return AuthPassword
+}
+
+// auth is the set of credentials sent with a single request
+type auth struct {
+	login    string
+	password string
+	apiKey   string
+	token    string
+	header   bool
+}
+
+// requestAuth resolves the credentials to send with a request
+// If using a token and we don't have one yet, login is called and the token is stored
+func requestAuth(creds Credentials, token *string, login func() (string, error)) (auth, error) {
+	switch creds.method() {
+	case AuthAPIKey:
+		return auth{apiKey: creds.APIKey, header: creds.Header}, nil
+	case AuthToken:
+		if *token == "" {
+			t, err := login()
+			if err != nil {
+				return auth{}, err
+			}
+			*token = t
+		}
+		return auth{token: *token, header: creds.Header}, nil
+	case AuthPassword:
+		return auth{login: creds.Username, password: creds.Password}, nil
+	default:
+		return auth{}, &Error{Kind: ErrorAuth, Message: "unknown auth method " + string(creds.Method)}
+	}
+}
+
+// setHeaders adds the api key or token to the request headers, if they are sent as headers
+func (a auth) setHeaders(req *http.Request) {
+	if !a.header {
+		return
+	}
+	if a.apiKey != "" {
+		req.Header.Set("X-BUGZILLA-API-KEY", a.apiKey)
+	}
+	if a.token != "" {
+		req.Header.Set("X-BUGZILLA-TOKEN", a.token)
+	}
+}
+
+// setLoginHeaders adds the username and password to the request headers, if using them
+// Used by the REST client to keep the password out of the url
+func (a auth) setLoginHeaders(req *http.Request) {
+	if a.login != "" {
+		req.Header.Set("X-BUGZILLA-LOGIN", a.login)
+		req.Header.Set("X-BUGZILLA-PASSWORD", a.password)
+	}
+}
+
+// setParams adds the api key or token to the url parameters, if they are not sent as headers
+func (a auth) setParams(params url.Values) {
+	if a.header {
+		return
+	}
+	if a.apiKey != "" {
+		params.Set("Bugzilla_api_key", a.apiKey)
+	}
+	if a.token != "" {
+		params.Set("Bugzilla_token", a.token)
+	}
+}
+
+// setRPCParams adds the credentials that are not sent as headers to the JSONRPC parameters
+func (a auth) setRPCParams(params map[string]interface{}) {
+	if a.login != "" {
+		params["Bugzilla_login"] = a.login
+		params["Bugzilla_password"] = a.password
+	}
+	if a.header {
+		return
+	}
+	if a.apiKey != "" {
+		params["Bugzilla_api_key"] = a.apiKey
+	}
+	if a.token != "" {
+		params["Bugzilla_token"] = a.token
+	}
+}
+
+// loginResult is the result of a successful User.login
+type loginResult struct {
+	ID    int    `json:"id"`
+	Token string `json:"token"`
+}
@@ -2,23 +2,58 @@ package bugzilla
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
-	"fmt"
 	"io/ioutil"
 	"net/http"
+	"time"
 )
 
-// Client knows how to execute a query for a saved search
+// Client knows how to execute a query for a saved search or search criteria
 // It represents a client for a bugzilla API
 type Client interface {
 	ExecuteQuery(query, sharer string, fields []string) (Bugs, error)
+	Search(criteria Criteria, fields []string) (Bugs, error)
+	History(ids []int) ([]BugHistory, error)
+	Comments(ids []int, options CommentOptions) ([]BugComments, error)
+}
+
+// ContextClient is a Client whose queries can be cancelled or given a deadline
+type ContextClient interface {
+	Client
+	ExecuteQueryContext(ctx context.Context, query, sharer string, fields []string) (Bugs, error)
+	SearchContext(ctx context.Context, criteria Criteria, fields []string) (Bugs, error)
+	HistoryContext(ctx context.Context, ids []int) ([]BugHistory, error)
+	CommentsContext(ctx context.Context, ids []int, options CommentOptions) ([]BugComments, error)
+}
+
+// Options holds the optional settings for a client
+type Options struct {
+	// PageSize is the number of bugs requested at a time.  Defaults to DefaultPageSize.
+	PageSize int
+	// HTTPClient is used for every request.  Defaults to http.DefaultClient.
+	HTTPClient *http.Client
+	// Retries is the number of times a request is retried after a temporary error
+	Retries int
+	// RetryWait is the wait before the first retry.  It doubles after each retry.
+	RetryWait time.Duration
+}
+
+// pageSize returns the page size to use for the options
+func (o Options) pageSize() int {
+	if o.PageSize <= 0 {
+		return DefaultPageSize
+	}
+	return o.PageSize
 }
 
 // httpBugzillaClient is a client for the bugzilla API that connects via JSONRPC over HTTP
 type httpBugzillaClient struct {
-	username string
-	password string
-	url      string
+	creds Credentials
+	// token is the login token when using AuthToken
+	token   string
+	url     string
+	options Options
 }
 
 // clientRequest is the JSONRPC wrapper structure for requests
@@ -30,68 +65,218 @@ type clientRequest struct {
 
 // clientResponse is the JSONRPC wrapper structure for responses
 type clientResponse struct {
-	ID     uint64      `json:"id"`
-	Result Bugs        `json:"result"`
-	Error  interface{} `json:"error"`
+	ID     uint64          `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *rpcError       `json:"error"`
 }
 
 // NewClient creates and returns a client
-func NewClient(user, pass, address string) Client {
+func NewClient(creds Credentials, address string, options Options) ContextClient {
 	return &httpBugzillaClient{
-		username: user,
-		password: pass,
-		url:      address,
+		creds:   creds,
+		url:     address,
+		options: options,
 	}
 }
 
-// arguments is the set of arguments for a "savedsearch" query with the bugzilla RPC
-type arguments struct {
-	BugzillaLogin    string   `json:"Bugzilla_login"`
-	BugzillaPassword string   `json:"Bugzilla_password"`
-	SavedSearch      string   `json:"savedsearch"`
-	SharerID         string   `json:"sharer_id"`
-	IncludeFields    []string `json:"include_fields"`
+// loginArguments is the set of arguments for User.login
+type loginArguments struct {
+	Login    string `json:"login"`
+	Password string `json:"password"`
 }
 
-// ExecuteQuery returns all bugs that match the given saved query
-func (bz *httpBugzillaClient) ExecuteQuery(query, sharer string, fields []string) (Bugs, error) {
-	// Prepare the http request
-	args := arguments{
-		BugzillaLogin:    bz.username,
-		BugzillaPassword: bz.password,
-		SavedSearch:      query,
-		SharerID:         sharer,
-		IncludeFields:    fields,
-	}
+// call sends a JSONRPC request and unmarshals the result, retrying on temporary errors
+// Failures reported by bugzilla or the connection are returned as an *Error
+func (bz *httpBugzillaClient) call(ctx context.Context, method string, params interface{}, a auth, result interface{}) error {
+	return withRetries(ctx, bz.options, func() error {
+		return bz.callOnce(ctx, method, params, a, result)
+	})
+}
 
+// callOnce sends a single JSONRPC request and unmarshals the result
+func (bz *httpBugzillaClient) callOnce(ctx context.Context, method string, params interface{}, a auth, result interface{}) error {
 	req := &clientRequest{
-		Method: "Bug.search",
-		Params: [1]interface{}{args},
+		Method: method,
+		Params: [1]interface{}{params},
 		ID:     0,
 	}
 
 	byteReq, err := json.Marshal(req)
 	if err != nil {
-		return Bugs{}, fmt.Errorf("unable to marshal http request: %v", err)
+		return newRequestError("unable to marshal http request: %v", err)
+	}
+
+	httpReq, err := http.NewRequest(http.MethodPost, bz.url, bytes.NewReader(byteReq))
+	if err != nil {
+		return newRequestError("unable to create http request: %v", err)
 	}
+	httpReq = httpReq.WithContext(ctx)
+	httpReq.Header.Set("Content-Type", "application/json")
+	a.setHeaders(httpReq)
 
-	// Send the query over post and parse the response
-	response, err := http.Post(bz.url, "application/json", bytes.NewReader(byteReq))
+	// Send the request over post and parse the response
+	response, err := bz.options.httpClient().Do(httpReq)
 	if err != nil {
-		return Bugs{}, fmt.Errorf("error when POSTing query: %v", err)
+		return newTransportError(0, "error when POSTing %s: %v", method, err)
 	}
 	defer response.Body.Close()
 
 	byteRes, err := ioutil.ReadAll(response.Body)
 	if err != nil {
-		return Bugs{}, fmt.Errorf("error reading from response body: %v", err)
+		return newTransportError(response.StatusCode, "error reading from response body: %v", err)
 	}
 
+	// Bugzilla may report a fault with a non-200 status, so check for a fault first
 	var results clientResponse
 	err = json.Unmarshal(byteRes, &results)
+	if err == nil && results.Error != nil {
+		return newFaultError(results.Error, response.StatusCode)
+	}
+	if response.StatusCode != http.StatusOK {
+		return newTransportError(response.StatusCode, "unexpected response status %q", response.Status)
+	}
 	if err != nil {
-		return Bugs{}, fmt.Errorf("unable to unmarshal http response: %v", err)
+		return newTransportError(response.StatusCode, "unable to unmarshal http response: %v", err)
 	}
 
-	return results.Result, nil
+	err = json.Unmarshal(results.Result, result)
+	if err != nil {
+		return newTransportError(response.StatusCode, "unable to unmarshal %s result: %v", method, err)
+	}
+
+	return nil
+}
+
+// login calls User.login and returns the token
+func (bz *httpBugzillaClient) login(ctx context.Context) (string, error) {
+	args := loginArguments{
+		Login:    bz.creds.Username,
+		Password: bz.creds.Password,
+	}
+
+	var result loginResult
+	err := bz.call(ctx, "User.login", args, auth{}, &result)
+	if err != nil {
+		return "", err
+	}
+	return result.Token, nil
+}
+
+// ExecuteQuery returns all bugs that match the given saved query
+func (bz *httpBugzillaClient) ExecuteQuery(query, sharer string, fields []string) (Bugs, error) {
+	return bz.ExecuteQueryContext(context.Background(), query, sharer, fields)
+}
+
+// ExecuteQueryContext returns all bugs that match the given saved query
+// Failures reported by bugzilla or the connection are returned as an *Error
+func (bz *httpBugzillaClient) ExecuteQueryContext(ctx context.Context, query, sharer string, fields []string) (Bugs, error) {
+	return bz.search(ctx, savedSearchParams(query, sharer), fields)
+}
+
+// Search returns all bugs that match the given criteria
+func (bz *httpBugzillaClient) Search(criteria Criteria, fields []string) (Bugs, error) {
+	return bz.SearchContext(context.Background(), criteria, fields)
+}
+
+// SearchContext returns all bugs that match the given criteria
+// Failures reported by bugzilla or the connection are returned as an *Error
+func (bz *httpBugzillaClient) SearchContext(ctx context.Context, criteria Criteria, fields []string) (Bugs, error) {
+	return bz.search(ctx, criteria.params(), fields)
+}
+
+// search calls Bug.search with the given parameters
+// The bugs are requested a page at a time and merged together
+func (bz *httpBugzillaClient) search(ctx context.Context, params searchParams, fields []string) (Bugs, error) {
+	a, err := requestAuth(bz.creds, &bz.token, func() (string, error) {
+		return bz.login(ctx)
+	})
+	if err != nil {
+		return Bugs{}, err
+	}
+
+	return fetchAll(bz.options.pageSize(), func(limit, offset int) (Bugs, error) {
+		args := make(map[string]interface{}, len(params)+6)
+		for name, value := range params {
+			args[name] = value
+		}
+		a.setRPCParams(args)
+		if len(fields) > 0 {
+			args["include_fields"] = fields
+		}
+		args["limit"] = limit
+		args["offset"] = offset
+
+		var bugs Bugs
+		err := bz.call(ctx, "Bug.search", args, a, &bugs)
+		return bugs, err
+	})
+}
+
+// History returns the change history of the given bugs
+func (bz *httpBugzillaClient) History(ids []int) ([]BugHistory, error) {
+	return bz.HistoryContext(context.Background(), ids)
+}
+
+// HistoryContext returns the change history of the given bugs using Bug.history
+// Failures reported by bugzilla or the connection are returned as an *Error
+func (bz *httpBugzillaClient) HistoryContext(ctx context.Context, ids []int) ([]BugHistory, error) {
+	a, err := requestAuth(bz.creds, &bz.token, func() (string, error) {
+		return bz.login(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var all []BugHistory
+	err = inBatches(ids, func(batch []int) error {
+		args := map[string]interface{}{
+			"ids": batch,
+		}
+		a.setRPCParams(args)
+
+		var result histories
+		err := bz.call(ctx, "Bug.history", args, a, &result)
+		all = append(all, result.Bugs...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return all, nil
+}
+
+// Comments returns the comments on the given bugs
+func (bz *httpBugzillaClient) Comments(ids []int, options CommentOptions) ([]BugComments, error) {
+	return bz.CommentsContext(context.Background(), ids, options)
+}
+
+// CommentsContext returns the comments on the given bugs using Bug.comments
+// Failures reported by bugzilla or the connection are returned as an *Error
+func (bz *httpBugzillaClient) CommentsContext(ctx context.Context, ids []int, options CommentOptions) ([]BugComments, error) {
+	a, err := requestAuth(bz.creds, &bz.token, func() (string, error) {
+		return bz.login(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var all []BugComments
+	err = inBatches(ids, func(batch []int) error {
+		args := map[string]interface{}{
+			"ids": batch,
+		}
+		a.setRPCParams(args)
+		if fields := options.fields(); len(fields) > 0 {
+			args["include_fields"] = fields
+		}
+
+		var result comments
+		err := bz.call(ctx, "Bug.comments", args, a, &result)
+		all = append(all, result.list(batch)...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return all, nil
 }
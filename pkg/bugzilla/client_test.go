@@ -0,0 +1,527 @@
+package bugzilla
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// newTestServer creates a fake bugzilla that replies with the given status and body
+// Any page after the first is empty
+func newTestServer(status int, body string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if offset(r) > 0 {
+			fmt.Fprint(w, `{"id": 0, "result": {"bugs": []}}`)
+			return
+		}
+		w.WriteHeader(status)
+		fmt.Fprint(w, body)
+	}))
+}
+
+// offset returns the offset parameter from a JSONRPC request
+func offset(r *http.Request) int {
+	var req struct {
+		Params [1]struct {
+			Offset int `json:"offset"`
+		} `json:"params"`
+	}
+	json.NewDecoder(r.Body).Decode(&req)
+	return req.Params[0].Offset
+}
+
+func TestExecuteQuery(t *testing.T) {
+	server := newTestServer(http.StatusOK, `{"id": 0, "error": null, "result": {"bugs": [
+		{"id": 1, "component": ["foo"], "target_release": ["1.0.0"], "cf_pm_score": "10"},
+		{"id": 2, "component": ["bar"], "target_release": ["---"], "cf_pm_score": "0"}
+	]}}`)
+	defer server.Close()
+
+	bugs, err := NewClient(Credentials{Username: "user", Password: "pass"}, server.URL, Options{}).ExecuteQuery("search", "1", nil)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(bugs.Bugs) != 2 {
+		t.Fatalf("expected 2 bugs, got %#v", bugs)
+	}
+	if bugs.Bugs[0].PrimaryComponent() != "foo" || bugs.Bugs[0].PmScore != 10 {
+		t.Errorf("unexpected bug: %#v", bugs.Bugs[0])
+	}
+}
+
+func TestExecuteQueryErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		kind   ErrorKind
+		code   int
+	}{
+		{
+			name:   "bad password",
+			status: http.StatusOK,
+			body:   `{"id": 0, "result": null, "error": {"code": 300, "message": "The username or password you entered is not valid."}}`,
+			kind:   ErrorAuth,
+			code:   300,
+		},
+		{
+			name:   "missing saved search",
+			status: http.StatusOK,
+			body:   `{"id": 0, "result": null, "error": {"code": 32000, "message": "The search named my_search does not exist."}}`,
+			kind:   ErrorSavedSearch,
+			code:   32000,
+		},
+		{
+			name:   "permission denied",
+			status: http.StatusOK,
+			body:   `{"id": 0, "result": null, "error": {"code": 102, "message": "You are not authorized to access bug #1."}}`,
+			kind:   ErrorPermission,
+			code:   102,
+		},
+		{
+			name:   "server fault with error status",
+			status: http.StatusInternalServerError,
+			body:   `{"id": 0, "result": null, "error": {"code": -32000, "message": "DBD::Pg::st execute failed"}}`,
+			kind:   ErrorServer,
+			code:   -32000,
+		},
+		{
+			name:   "bad gateway",
+			status: http.StatusBadGateway,
+			body:   `<html>Bad Gateway</html>`,
+			kind:   ErrorTransport,
+		},
+	}
+
+	for _, test := range tests {
+		server := newTestServer(test.status, test.body)
+		_, err := NewClient(Credentials{Username: "user", Password: "pass"}, server.URL, Options{}).ExecuteQuery("search", "1", nil)
+		server.Close()
+
+		bzErr, ok := err.(*Error)
+		if !ok {
+			t.Errorf("%s: expected *Error, got %#v", test.name, err)
+			continue
+		}
+		if bzErr.Kind != test.kind {
+			t.Errorf("%s: expected kind %q, got %q", test.name, test.kind, bzErr.Kind)
+		}
+		if bzErr.Code != test.code {
+			t.Errorf("%s: expected code %d, got %d", test.name, test.code, bzErr.Code)
+		}
+		if bzErr.StatusCode != test.status {
+			t.Errorf("%s: expected status %d, got %d", test.name, test.status, bzErr.StatusCode)
+		}
+	}
+}
+
+func TestRESTExecuteQuery(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/bug" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.URL.Query().Get("savedsearch") != "search" || r.URL.Query().Get("include_fields") != "id,component" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if r.URL.Query().Get("offset") != "0" {
+			fmt.Fprint(w, `{"bugs": []}`)
+			return
+		}
+		if r.Header.Get("X-BUGZILLA-LOGIN") != "user" {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"error": true, "code": 410, "message": "You must log in before using this part of Bugzilla."}`)
+			return
+		}
+		fmt.Fprint(w, `{"bugs": [{"id": 1, "component": ["foo"]}]}`)
+	}))
+	defer server.Close()
+
+	bugs, err := NewRESTClient(Credentials{Username: "user", Password: "pass"}, server.URL+"/rest/", Options{}).ExecuteQuery("search", "1", []string{"id", "component"})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(bugs.Bugs) != 1 || bugs.Bugs[0].PrimaryComponent() != "foo" {
+		t.Fatalf("unexpected bugs: %#v", bugs)
+	}
+
+	_, err = NewRESTClient(Credentials{}, server.URL+"/rest", Options{}).ExecuteQuery("search", "1", []string{"id", "component"})
+	bzErr, ok := err.(*Error)
+	if !ok {
+		t.Fatalf("expected *Error, got %#v", err)
+	}
+	if bzErr.Kind != ErrorAuth || bzErr.Code != 410 || bzErr.StatusCode != http.StatusUnauthorized {
+		t.Errorf("unexpected error: %#v", bzErr)
+	}
+}
+
+func TestAuth(t *testing.T) {
+	// The fake bugzilla only returns bugs for requests with the api key or login token
+	var logins int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string
+			Params [1]map[string]interface{}
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		params := req.Params[0]
+		if params["offset"] != nil && params["offset"].(float64) > 0 {
+			fmt.Fprint(w, `{"id": 0, "result": {"bugs": []}}`)
+			return
+		}
+
+		if req.Method == "User.login" {
+			logins++
+			fmt.Fprint(w, `{"id": 0, "result": {"id": 1, "token": "1-abcdef"}}`)
+			return
+		}
+		if _, ok := params["Bugzilla_password"]; ok {
+			fmt.Fprint(w, `{"id": 0, "error": {"code": 300, "message": "password sent"}}`)
+			return
+		}
+		if params["Bugzilla_api_key"] == "key" || r.Header.Get("X-BUGZILLA-API-KEY") == "key" || params["Bugzilla_token"] == "1-abcdef" {
+			fmt.Fprint(w, `{"id": 0, "result": {"bugs": [{"id": 1}]}}`)
+			return
+		}
+		fmt.Fprint(w, `{"id": 0, "error": {"code": 306, "message": "The API key you specified is invalid."}}`)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name  string
+		creds Credentials
+	}{
+		{name: "api key param", creds: Credentials{APIKey: "key", Username: "user", Password: "pass"}},
+		{name: "api key header", creds: Credentials{APIKey: "key", Header: true}},
+		{name: "token", creds: Credentials{Method: AuthToken, Username: "user", Password: "pass"}},
+	}
+	for _, test := range tests {
+		client := NewClient(test.creds, server.URL, Options{})
+		// Run twice to check that the token is reused
+		for i := 0; i < 2; i++ {
+			bugs, err := client.ExecuteQuery("search", "1", nil)
+			if err != nil {
+				t.Errorf("%s: unexpected err: %v", test.name, err)
+			} else if len(bugs.Bugs) != 1 {
+				t.Errorf("%s: expected 1 bug, got %#v", test.name, bugs)
+			}
+		}
+	}
+	if logins != 1 {
+		t.Errorf("expected a single login, got %d", logins)
+	}
+}
+
+func TestPagination(t *testing.T) {
+	// The fake bugzilla caps pages at 3 bugs (below our page size) and
+	// shifts the results by one after the first page, repeating bug 3
+	all := []int{1, 2, 3, 4, 5, 6, 7}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := offset(r)
+		if start > 0 {
+			start--
+		}
+		var page Bugs
+		for i := start; i < len(all) && i < start+3; i++ {
+			page.Bugs = append(page.Bugs, Bug{ID: all[i]})
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"id": 0, "result": page})
+	}))
+	defer server.Close()
+
+	bugs, err := NewClient(Credentials{}, server.URL, Options{PageSize: 5}).ExecuteQuery("search", "1", nil)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(bugs.Bugs) != len(all) {
+		t.Fatalf("expected %d bugs, got %#v", len(all), bugs)
+	}
+	for i, bug := range bugs.Bugs {
+		if bug.ID != all[i] {
+			t.Errorf("expected bug %d at index %d, got %d", all[i], i, bug.ID)
+		}
+	}
+}
+
+func TestRetries(t *testing.T) {
+	// The fake bugzilla fails twice before succeeding
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if offset(r) > 0 {
+			fmt.Fprint(w, `{"id": 0, "result": {"bugs": []}}`)
+			return
+		}
+		requests++
+		if requests <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"id": 0, "result": {"bugs": [{"id": 1}]}}`)
+	}))
+	defer server.Close()
+
+	_, err := NewClient(Credentials{}, server.URL, Options{Retries: 1, RetryWait: time.Millisecond}).ExecuteQuery("search", "1", nil)
+	if bzErr, ok := err.(*Error); !ok || bzErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("expected a 503 error after one retry, got %v", err)
+	}
+
+	requests = 0
+	bugs, err := NewClient(Credentials{}, server.URL, Options{Retries: 2, RetryWait: time.Millisecond}).ExecuteQuery("search", "1", nil)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(bugs.Bugs) != 1 {
+		t.Fatalf("expected 1 bug, got %#v", bugs)
+	}
+}
+
+func TestRequestErrorsAreNotRetried(t *testing.T) {
+	// A channel can't be marshalled, so the request fails before it is sent
+	options := Options{Retries: 3, RetryWait: time.Hour}
+	bz := NewClient(Credentials{}, "http://bugzilla.invalid", options).(*httpBugzillaClient)
+	var attempts int
+	err := withRetries(context.Background(), options, func() error {
+		attempts++
+		return bz.callOnce(context.Background(), "Bug.search", make(chan int), auth{}, nil)
+	})
+	bzErr, ok := err.(*Error)
+	if !ok || bzErr.Kind != ErrorRequest || bzErr.Temporary() {
+		t.Fatalf("expected a request error, got %v", err)
+	}
+	if attempts != 1 {
+		t.Errorf("expected 1 attempt, got %d", attempts)
+	}
+}
+
+func TestExecuteQueryContext(t *testing.T) {
+	// The fake bugzilla never answers in time
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := NewClient(Credentials{}, server.URL, Options{Retries: 5}).ExecuteQueryContext(ctx, "search", "1", nil)
+	bzErr, ok := err.(*Error)
+	if !ok || bzErr.Kind != ErrorTransport {
+		t.Fatalf("expected a transport error, got %v", err)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	criteria := Criteria{
+		Product:  []string{"my_product"},
+		Status:   []string{"NEW", "ASSIGNED"},
+		Keywords: []string{"TestBlocker", "OpsBlocker"},
+		Flags:    []string{"blocker+", "blocker?"},
+		Filters: []Filter{
+			{Field: "cf_pm_score", Operator: "greaterthan", Value: "10"},
+		},
+	}
+	expected := map[string]interface{}{
+		"product":       []interface{}{"my_product"},
+		"status":        []interface{}{"NEW", "ASSIGNED"},
+		"keywords":      "TestBlocker,OpsBlocker",
+		"keywords_type": "anywords",
+		"f1":            "flagtypes.name",
+		"o1":            "anyexact",
+		"v1":            "blocker+,blocker?",
+		"f2":            "cf_pm_score",
+		"o2":            "greaterthan",
+		"v2":            "10",
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Params [1]map[string]interface{}
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		params := req.Params[0]
+		if params["offset"].(float64) > 0 {
+			fmt.Fprint(w, `{"id": 0, "result": {"bugs": []}}`)
+			return
+		}
+		for name, value := range expected {
+			if fmt.Sprint(params[name]) != fmt.Sprint(value) {
+				t.Errorf("expected param %s to be %v, got %v", name, value, params[name])
+			}
+		}
+		if _, ok := params["savedsearch"]; ok {
+			t.Errorf("unexpected savedsearch param")
+		}
+		fmt.Fprint(w, `{"id": 0, "result": {"bugs": [{"id": 1}]}}`)
+	}))
+	defer server.Close()
+
+	bugs, err := NewClient(Credentials{}, server.URL, Options{}).Search(criteria, nil)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(bugs.Bugs) != 1 {
+		t.Fatalf("expected 1 bug, got %#v", bugs)
+	}
+
+	if err := (Criteria{}).Validate(); err == nil {
+		t.Errorf("expected empty criteria to be invalid")
+	}
+}
+
+func TestHistory(t *testing.T) {
+	// Enough bugs for two batches
+	ids := make([]int, BatchSize+1)
+	for i := range ids {
+		ids[i] = i + 1
+	}
+
+	batches := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string
+			Params [1]struct {
+				IDs []int `json:"ids"`
+			}
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.Method != "Bug.history" {
+			t.Errorf("expected Bug.history, got %q", req.Method)
+		}
+		batches++
+
+		var result histories
+		for _, id := range req.Params[0].IDs {
+			result.Bugs = append(result.Bugs, BugHistory{ID: id, History: []HistoryEntry{{
+				When:    time.Date(2018, 1, 1, 12, 0, 0, 0, time.UTC),
+				Who:     "user@example.com",
+				Changes: []Change{{FieldName: "status", Removed: "NEW", Added: "ASSIGNED"}},
+			}}})
+		}
+		body, _ := json.Marshal(result)
+		fmt.Fprintf(w, `{"id": 0, "result": %s}`, body)
+	}))
+	defer server.Close()
+
+	history, err := NewClient(Credentials{}, server.URL, Options{}).History(ids)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if batches != 2 {
+		t.Errorf("expected 2 batches, got %d", batches)
+	}
+	if len(history) != len(ids) {
+		t.Fatalf("expected %d histories, got %d", len(ids), len(history))
+	}
+	change := history[0].History[0].Changes[0]
+	if history[0].ID != 1 || change.FieldName != "status" || change.Added != "ASSIGNED" {
+		t.Errorf("unexpected history: %#v", history[0])
+	}
+}
+
+func TestRESTHistory(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/bug/1/history" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if fmt.Sprint(r.URL.Query()["ids"]) != "[2]" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		fmt.Fprint(w, `{"bugs": [
+			{"id": 1, "history": [{"when": "2018-01-01T12:00:00Z", "who": "user@example.com", "changes": [{"field_name": "status", "removed": "NEW", "added": "ASSIGNED"}]}]},
+			{"id": 2, "history": []}
+		]}`)
+	}))
+	defer server.Close()
+
+	history, err := NewRESTClient(Credentials{}, server.URL+"/rest", Options{}).History([]int{1, 2})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(history) != 2 || len(history[0].History) != 1 {
+		t.Fatalf("unexpected history: %#v", history)
+	}
+	if !history[0].History[0].When.Equal(time.Date(2018, 1, 1, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected change time: %v", history[0].History[0].When)
+	}
+}
+
+func TestComments(t *testing.T) {
+	var includeFields interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Params [1]map[string]interface{}
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		includeFields = req.Params[0]["include_fields"]
+		fmt.Fprint(w, `{"id": 0, "result": {"comments": {}, "bugs": {
+			"1": {"comments": [
+				{"id": 10, "bug_id": 1, "count": 0, "text": "description", "creator": "reporter@example.com", "creation_time": "2018-01-01T12:00:00Z"},
+				{"id": 11, "bug_id": 1, "count": 1, "text": "comment", "creator": "dev@example.com", "creation_time": "2018-01-02T12:00:00Z"}
+			]},
+			"2": {"comments": []}
+		}}}`)
+	}))
+	defer server.Close()
+
+	bugs, err := NewClient(Credentials{}, server.URL, Options{}).Comments([]int{1, 2}, CommentOptions{})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(bugs) != 2 || bugs[0].ID != 1 || len(bugs[0].Comments) != 2 {
+		t.Fatalf("unexpected comments: %#v", bugs)
+	}
+	if fmt.Sprint(includeFields) != "[id creator creation_time count]" {
+		t.Errorf("expected only the summary fields to be requested, got %v", includeFields)
+	}
+
+	summary := bugs[0].Summary()
+	if summary.Count != 2 || summary.LastCommenter != "dev@example.com" || !summary.LastCommentTime.Equal(time.Date(2018, 1, 2, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected summary: %#v", summary)
+	}
+	if summary := bugs[1].Summary(); summary.Count != 0 || !summary.LastCommentTime.IsZero() {
+		t.Errorf("unexpected summary for a bug without comments: %#v", summary)
+	}
+
+	_, err = NewClient(Credentials{}, server.URL, Options{}).Comments([]int{1}, CommentOptions{Text: true})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if includeFields != nil {
+		t.Errorf("expected every field to be requested with the text, got %v", includeFields)
+	}
+}
+
+func TestRESTComments(t *testing.T) {
+	var includeFields []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		includeFields = r.URL.Query()["include_fields"]
+		fmt.Fprint(w, `{"bugs": {"1": {"comments": [{"id": 10, "bug_id": 1, "count": 0, "creator": "reporter@example.com", "creation_time": "2018-01-01T12:00:00Z"}]}}}`)
+	}))
+	defer server.Close()
+
+	client := NewRESTClient(Credentials{}, server.URL+"/rest", Options{})
+	bugs, err := client.Comments([]int{1}, CommentOptions{})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(bugs) != 1 || len(bugs[0].Comments) != 1 {
+		t.Fatalf("unexpected comments: %#v", bugs)
+	}
+	if fmt.Sprint(includeFields) != "[id,creator,creation_time,count]" {
+		t.Errorf("expected only the summary fields to be requested, got %v", includeFields)
+	}
+
+	_, err = client.Comments([]int{1}, CommentOptions{Text: true})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(includeFields) != 0 {
+		t.Errorf("expected every field to be requested with the text, got %v", includeFields)
+	}
+}
@@ -0,0 +1,81 @@
+package bugzilla
+
+import (
+	"time"
+)
+
+// CommentOptions configures which parts of the comments are returned
+type CommentOptions struct {
+	// Text requests the comment text, which is left out by default to save bandwidth and memory
+	Text bool
+}
+
+// summaryFields are the comment fields requested when the text is left out
+var summaryFields = []string{"id", "creator", "creation_time", "count"}
+
+// fields returns the comment fields to request, or nil for every field
+func (o CommentOptions) fields() []string {
+	if o.Text {
+		return nil
+	}
+	return summaryFields
+}
+
+// Comment is a single comment on a bug
+// The description of the bug is the first comment, with a count of 0
+type Comment struct {
+	ID           int       `json:"id"`
+	BugID        int       `json:"bug_id"`
+	Count        int       `json:"count"`
+	Text         string    `json:"text"`
+	Creator      string    `json:"creator"`
+	CreationTime time.Time `json:"creation_time"`
+	IsPrivate    bool      `json:"is_private"`
+}
+
+// BugComments is the list of comments on a single bug, oldest first
+type BugComments struct {
+	ID       int
+	Comments []Comment
+}
+
+// CommentSummary is the activity metadata of a bug's comments
+type CommentSummary struct {
+	// Count includes the description
+	Count           int
+	LastCommentTime time.Time
+	LastCommenter   string
+}
+
+// Summary returns the comment count and the time and author of the latest comment
+func (c BugComments) Summary() CommentSummary {
+	summary := CommentSummary{Count: len(c.Comments)}
+	for _, comment := range c.Comments {
+		if !comment.CreationTime.Before(summary.LastCommentTime) {
+			summary.LastCommentTime = comment.CreationTime
+			summary.LastCommenter = comment.Creator
+		}
+	}
+	return summary
+}
+
+// comments is the result of Bug.comments (or GET /rest/bug/{id}/comment)
+// Bugs are keyed by id
+type comments struct {
+	Bugs map[int]struct {
+		Comments []Comment `json:"comments"`
+	} `json:"bugs"`
+}
+
+// list converts the result into a list of comments per bug, in the order of the given ids
+func (c comments) list(ids []int) []BugComments {
+	var all []BugComments
+	for _, id := range ids {
+		bug, ok := c.Bugs[id]
+		if !ok {
+			continue
+		}
+		all = append(all, BugComments{ID: id, Comments: bug.Comments})
+	}
+	return all
+}
@@ -1,9 +1,22 @@
-/*  Package bugzilla implements a basic bugzilla jsonrpc api wrapper with a single query
+/*  Package bugzilla implements a basic bugzilla jsonrpc and REST api wrapper with a single query
 
 Create a new Client with the required credentials.  You'll then be able
 to call ExecuteQuery with a saved search.  This is based on Red Hat's
 Bugzilla - so the saved search feature may not exist for your desired
-bugzilla instance.
+bugzilla instance.  Search takes Criteria (product, component, status, flags,
+custom field filters...) instead, so no saved search is needed.
+
+NewClient talks to the JSONRPC endpoint (jsonrpc.cgi) and NewRESTClient talks
+to the bugzilla 5 REST API (/rest/bug).  Both have the same ExecuteQuery
+and Search semantics.  Results are requested a page at a time (see Options) so large
+searches are not truncated by the server's result limit.  History returns
+the change history of a list of bugs (Bug.history), a batch at a time.
+Comments (Bug.comments) works the same way, and its Summary gives the count and
+latest comment without keeping the text.
+
+Failures are returned as an *Error.  The Kind field separates rejected
+credentials, missing saved searches, permission problems, bugzilla faults,
+and transport failures, while Code and Message preserve what bugzilla said.
 
 */
 package bugzilla
@@ -0,0 +1,134 @@
+package bugzilla
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ErrorKind classifies why a request to bugzilla failed
+type ErrorKind int
+
+const (
+	// ErrorUnknown is a bugzilla fault that we don't have a better category for
+	ErrorUnknown ErrorKind = iota
+	// ErrorAuth means the credentials were rejected or are missing
+	ErrorAuth
+	// ErrorSavedSearch means the saved search does not exist or is not shared with the user
+	ErrorSavedSearch
+	// ErrorPermission means the user is logged in but cannot access the requested data
+	ErrorPermission
+	// ErrorServer means bugzilla reported an internal fault
+	ErrorServer
+	// ErrorTransport means we never got a usable JSONRPC response (network failure or non-200 status)
+	ErrorTransport
+	// ErrorRequest means the request could not be built, so it was never sent
+	ErrorRequest
+)
+
+// String returns a short name for the kind of error, suitable for logging
+func (k ErrorKind) String() string {
+	switch k {
+	case ErrorAuth:
+		return "auth"
+	case ErrorSavedSearch:
+		return "saved search"
+	case ErrorPermission:
+		return "permission"
+	case ErrorServer:
+		return "server"
+	case ErrorTransport:
+		return "transport"
+	case ErrorRequest:
+		return "request"
+	default:
+		return "unknown"
+	}
+}
+
+// Error is returned by the client when bugzilla (or the connection to it) fails
+// Code and Message are preserved from the bugzilla fault, if there was one
+type Error struct {
+	Kind ErrorKind
+	// Code is the bugzilla (or JSONRPC) fault code.  Zero if there was no fault.
+	Code int
+	// Message is the fault string from bugzilla or a description of the transport failure
+	Message string
+	// StatusCode is the HTTP status of the response.  Zero if no response was received.
+	StatusCode int
+}
+
+func (e *Error) Error() string {
+	if e.Code != 0 {
+		return fmt.Sprintf("bugzilla %s error (code %d): %s", e.Kind, e.Code, e.Message)
+	}
+	if e.StatusCode != 0 {
+		return fmt.Sprintf("bugzilla %s error (http %d): %s", e.Kind, e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("bugzilla %s error: %s", e.Kind, e.Message)
+}
+
+// rpcError is the JSONRPC error object returned by bugzilla
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// faultKinds maps the bugzilla webservice fault codes we care about to an ErrorKind
+// See Bugzilla::WebService::Constants for the full list
+var faultKinds = map[int]ErrorKind{
+	// Login errors
+	300: ErrorAuth, // invalid username or password
+	301: ErrorAuth, // account disabled
+	302: ErrorAuth, // invalid email
+	305: ErrorAuth, // invalid token
+	306: ErrorAuth, // invalid or revoked api key
+	307: ErrorAuth, // token/cookie mismatch
+	410: ErrorAuth, // login required
+	// Access errors
+	102: ErrorPermission, // bug access denied
+	304: ErrorPermission, // not authorized for this action
+	// Unhandled or internal errors
+	32000:  ErrorServer,
+	-32000: ErrorServer,
+	-32603: ErrorServer, // JSONRPC internal error
+	-32700: ErrorServer, // JSONRPC parse error
+}
+
+// newFaultError classifies a JSONRPC error returned by bugzilla
+func newFaultError(fault *rpcError, statusCode int) *Error {
+	kind, ok := faultKinds[fault.Code]
+	if !ok {
+		kind = ErrorUnknown
+	}
+	// Bugzilla does not have a dedicated code for a missing saved search
+	// so we have to recognize it from the message
+	msg := strings.ToLower(fault.Message)
+	if strings.Contains(msg, "search named") || strings.Contains(msg, "saved search") {
+		kind = ErrorSavedSearch
+	}
+
+	return &Error{
+		Kind:       kind,
+		Code:       fault.Code,
+		Message:    fault.Message,
+		StatusCode: statusCode,
+	}
+}
+
+// newTransportError creates an error for a request that did not get a usable response
+func newTransportError(statusCode int, format string, args ...interface{}) *Error {
+	return &Error{
+		Kind:       ErrorTransport,
+		Message:    fmt.Sprintf(format, args...),
+		StatusCode: statusCode,
+	}
+}
+
+// newRequestError creates an error for a request that could not be built
+// These are never retried, as building the request again would fail the same way
+func newRequestError(format string, args ...interface{}) *Error {
+	return &Error{
+		Kind:    ErrorRequest,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
@@ -0,0 +1,144 @@
+package bugzilla
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// Tracker meanings used to configure which external trackers are which
+const (
+	TrackerCustomerPortal = "customer_portal"
+	TrackerGitHub         = "github"
+	TrackerJira           = "jira"
+	TrackerErrata         = "errata"
+)
+
+// ExternalBug is a link from a bug to an issue in another tracker
+type ExternalBug struct {
+	// TrackerID is the bugzilla id of the external tracker, ex) 60 for the customer portal
+	TrackerID   int
+	TrackerName string
+	// ExternalID is the id of the issue in the external tracker, ex) a case number
+	ExternalID string
+	Status     string
+	Priority   string
+}
+
+// externalTracker is the type of an external bug in the bugzilla json
+type externalTracker struct {
+	ID          int    `json:"id"`
+	Description string `json:"description"`
+}
+
+// externalBug is the bugzilla json for an external bug
+// ExternalID is raw as some trackers use numbers for their ids
+type externalBug struct {
+	TrackerID  int             `json:"ext_bz_id"`
+	ExternalID json.RawMessage `json:"ext_bz_bug_id"`
+	Status     string          `json:"ext_status"`
+	Priority   string          `json:"ext_priority"`
+	Type       externalTracker `json:"type"`
+}
+
+// MarshalJSON converts the external bug back to the bugzilla json, so that stored bugs can be queried the same way
+func (e ExternalBug) MarshalJSON() ([]byte, error) {
+	id, err := json.Marshal(e.ExternalID)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(externalBug{
+		TrackerID:  e.TrackerID,
+		ExternalID: id,
+		Status:     e.Status,
+		Priority:   e.Priority,
+		Type:       externalTracker{ID: e.TrackerID, Description: e.TrackerName},
+	})
+}
+
+// UnmarshalJSON converts the bugzilla json for an external bug
+func (e *ExternalBug) UnmarshalJSON(b []byte) error {
+	var ext externalBug
+	err := json.Unmarshal(b, &ext)
+	if err != nil {
+		return err
+	}
+
+	// The id is usually a string, but fall back to the raw number
+	var id string
+	if len(ext.ExternalID) > 0 && json.Unmarshal(ext.ExternalID, &id) != nil {
+		id = string(ext.ExternalID)
+	}
+
+	*e = ExternalBug{
+		TrackerID:   ext.TrackerID,
+		TrackerName: ext.Type.Description,
+		ExternalID:  id,
+		Status:      ext.Status,
+		Priority:    ext.Priority,
+	}
+	if e.TrackerID == 0 {
+		e.TrackerID = ext.Type.ID
+	}
+	return nil
+}
+
+// ExternalBugs is the list of a bug's external bugs
+// It is stored in the database as jsonb in the bugzilla format
+type ExternalBugs []ExternalBug
+
+// InTrackers returns whether any of the external bugs belong to one of the given trackers
+func (e ExternalBugs) InTrackers(trackerIDs []int) bool {
+	for _, ext := range e {
+		if ext.InTrackers(trackerIDs) {
+			return true
+		}
+	}
+	return false
+}
+
+// InTrackers is whether the external bug is in any of the given trackers
+func (e ExternalBug) InTrackers(trackerIDs []int) bool {
+	for _, id := range trackerIDs {
+		if e.TrackerID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// Value converts the external bugs to json
+// A nil list is stored as an empty array rather than NULL
+func (e ExternalBugs) Value() (driver.Value, error) {
+	if e == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal([]ExternalBug(e))
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan reads json from the database into the external bugs
+func (e *ExternalBugs) Scan(src interface{}) error {
+	var b []byte
+	switch v := src.(type) {
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	case nil:
+		*e = nil
+		return nil
+	default:
+		return fmt.Errorf("cannot scan %T into external bugs", src)
+	}
+	var list []ExternalBug
+	err := json.Unmarshal(b, &list)
+	if err != nil {
+		return err
+	}
+	*e = ExternalBugs(list)
+	return nil
+}
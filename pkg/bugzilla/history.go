@@ -0,0 +1,31 @@
+package bugzilla
+
+import (
+	"time"
+)
+
+// BugHistory is the list of changes made to a single bug
+type BugHistory struct {
+	ID      int            `json:"id"`
+	History []HistoryEntry `json:"history"`
+}
+
+// HistoryEntry is a set of changes made by one person at the same time
+type HistoryEntry struct {
+	When    time.Time `json:"when"`
+	Who     string    `json:"who"`
+	Changes []Change  `json:"changes"`
+}
+
+// Change is a change to a single field of a bug
+// Fields that hold a list only show the values that were removed or added
+type Change struct {
+	FieldName string `json:"field_name"`
+	Removed   string `json:"removed"`
+	Added     string `json:"added"`
+}
+
+// histories is the result of Bug.history (or GET /rest/bug/{id}/history)
+type histories struct {
+	Bugs []BugHistory `json:"bugs"`
+}
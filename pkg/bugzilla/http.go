@@ -0,0 +1,106 @@
+package bugzilla
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// DefaultRetryWait is the wait before the first retry if no wait is given
+const DefaultRetryWait = time.Second
+
+// HTTPOptions configures the http client used to talk to bugzilla
+type HTTPOptions struct {
+	// Timeout is the limit for a single request.  Zero means no limit.
+	Timeout time.Duration
+	// Proxy is the url of the proxy to use.  Defaults to the proxy environment variables.
+	Proxy string
+	// CABundle is the path to a PEM file of extra certificate authorities to trust
+	CABundle string
+}
+
+// NewHTTPClient creates an http client for the given options
+func NewHTTPClient(o HTTPOptions) (*http.Client, error) {
+	transport := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+	}
+
+	if o.Proxy != "" {
+		proxy, err := url.Parse(o.Proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy url %q: %v", o.Proxy, err)
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	if o.CABundle != "" {
+		pem, err := ioutil.ReadFile(o.CABundle)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read ca bundle: %v", err)
+		}
+		// Add to the system CAs rather than replacing them
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in ca bundle %q", o.CABundle)
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	}
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   o.Timeout,
+	}, nil
+}
+
+// httpClient returns the http client to use for the options
+func (o Options) httpClient() *http.Client {
+	if o.HTTPClient == nil {
+		return http.DefaultClient
+	}
+	return o.HTTPClient
+}
+
+// Temporary is whether the request may succeed if it is tried again
+// Failures to send the request and server (5xx) errors are temporary, while requests that couldn't be built are not
+func (e *Error) Temporary() bool {
+	if e.StatusCode >= http.StatusInternalServerError {
+		return true
+	}
+	return e.Kind == ErrorTransport && e.StatusCode == 0
+}
+
+// withRetries calls do until it succeeds, returns an error that is not temporary,
+// or runs out of retries.  The wait between attempts doubles each time.
+func withRetries(ctx context.Context, o Options, do func() error) error {
+	wait := o.RetryWait
+	if wait <= 0 {
+		wait = DefaultRetryWait
+	}
+
+	for attempt := 0; ; attempt++ {
+		err := do()
+		if err == nil {
+			return nil
+		}
+		// Give up if we are out of retries, the error won't go away, or we've been cancelled
+		bzErr, ok := err.(*Error)
+		if attempt >= o.Retries || !ok || !bzErr.Temporary() || ctx.Err() != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(wait):
+		}
+		wait *= 2
+	}
+}
@@ -0,0 +1,74 @@
+package bugzilla
+
+import (
+	"log"
+)
+
+// DefaultPageSize is the number of bugs requested per page if no page size is given
+const DefaultPageSize = 1000
+
+// BatchSize is the number of bugs requested at a time by methods that take a list of ids
+// Histories and comments can be long, so this is much smaller than the search page size
+const BatchSize = 100
+
+// fetchPage requests a single page of bugs with the given limit and offset
+type fetchPage func(limit, offset int) (Bugs, error)
+
+// fetchAll keeps requesting pages until bugzilla runs out of bugs and merges them together
+// We only stop on an empty page, as the server may cap the results below our limit.
+// Bugs that appear on more than one page (the results shifted while paging) are only kept once.
+func fetchAll(limit int, fetch fetchPage) (Bugs, error) {
+	var all Bugs
+	seen := make(map[int]bool)
+	duplicates := 0
+
+	for offset := 0; ; {
+		page, err := fetch(limit, offset)
+		if err != nil {
+			return Bugs{}, err
+		}
+		if len(page.Bugs) == 0 {
+			break
+		}
+		offset += len(page.Bugs)
+
+		added := 0
+		for _, bug := range page.Bugs {
+			if seen[bug.ID] {
+				duplicates++
+				continue
+			}
+			seen[bug.ID] = true
+			all.Bugs = append(all.Bugs, bug)
+			added++
+		}
+
+		// If the server ignores the offset we would get the same page forever
+		if added == 0 {
+			log.Printf("Page at offset %d only had duplicate bugs, stopping", offset-len(page.Bugs))
+			break
+		}
+	}
+
+	if duplicates > 0 {
+		log.Printf("Skipped %d duplicate bugs while paging, the query results changed during the snapshot", duplicates)
+	}
+
+	return all, nil
+}
+
+// inBatches calls do for each batch of ids, stopping at the first error
+func inBatches(ids []int, do func(batch []int) error) error {
+	for start := 0; start < len(ids); start += BatchSize {
+		end := start + BatchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		err := do(ids[start:end])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,232 @@
+package bugzilla
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// restBugzillaClient is a client for the bugzilla 5 REST API
+type restBugzillaClient struct {
+	creds Credentials
+	// token is the login token when using AuthToken
+	token string
+	// url is the base of the REST API, ex) https://bugzilla.example.com/rest
+	url     string
+	options Options
+}
+
+// restError is the body of a failed REST request
+type restError struct {
+	Error   bool   `json:"error"`
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// NewRESTClient creates and returns a client that uses the REST API
+// The address is the base of the REST API rather than a specific endpoint
+func NewRESTClient(creds Credentials, address string, options Options) ContextClient {
+	return &restBugzillaClient{
+		creds:   creds,
+		url:     strings.TrimSuffix(address, "/"),
+		options: options,
+	}
+}
+
+// get sends a GET request to the given path and unmarshals the response, retrying on temporary errors
+// Failures reported by bugzilla or the connection are returned as an *Error
+func (bz *restBugzillaClient) get(ctx context.Context, path string, params url.Values, a auth, result interface{}) error {
+	a.setParams(params)
+	return withRetries(ctx, bz.options, func() error {
+		return bz.getOnce(ctx, path, params, a, result)
+	})
+}
+
+// getOnce sends a single GET request to the given path and unmarshals the response
+func (bz *restBugzillaClient) getOnce(ctx context.Context, path string, params url.Values, a auth, result interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, bz.url+path+"?"+params.Encode(), nil)
+	if err != nil {
+		return newRequestError("unable to create http request: %v", err)
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Accept", "application/json")
+	a.setHeaders(req)
+	a.setLoginHeaders(req)
+
+	// Send the request and parse the response
+	response, err := bz.options.httpClient().Do(req)
+	if err != nil {
+		return newTransportError(0, "error when GETting %s: %v", path, err)
+	}
+	defer response.Body.Close()
+
+	byteRes, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return newTransportError(response.StatusCode, "error reading from response body: %v", err)
+	}
+
+	// Failures come back with an error status, but still have a fault in the body
+	var fault restError
+	err = json.Unmarshal(byteRes, &fault)
+	if err == nil && fault.Error {
+		return newFaultError(&rpcError{Code: fault.Code, Message: fault.Message}, response.StatusCode)
+	}
+	if response.StatusCode != http.StatusOK {
+		return newTransportError(response.StatusCode, "unexpected response status %q", response.Status)
+	}
+
+	err = json.Unmarshal(byteRes, result)
+	if err != nil {
+		return newTransportError(response.StatusCode, "unable to unmarshal http response: %v", err)
+	}
+
+	return nil
+}
+
+// login calls GET /rest/login and returns the token
+func (bz *restBugzillaClient) login(ctx context.Context) (string, error) {
+	params := url.Values{}
+	params.Set("login", bz.creds.Username)
+	params.Set("password", bz.creds.Password)
+
+	var result loginResult
+	err := bz.get(ctx, "/login", params, auth{}, &result)
+	if err != nil {
+		return "", err
+	}
+	return result.Token, nil
+}
+
+// ExecuteQuery returns all bugs that match the given saved query
+func (bz *restBugzillaClient) ExecuteQuery(query, sharer string, fields []string) (Bugs, error) {
+	return bz.ExecuteQueryContext(context.Background(), query, sharer, fields)
+}
+
+// ExecuteQueryContext returns all bugs that match the given saved query
+// Failures reported by bugzilla or the connection are returned as an *Error
+func (bz *restBugzillaClient) ExecuteQueryContext(ctx context.Context, query, sharer string, fields []string) (Bugs, error) {
+	return bz.search(ctx, savedSearchParams(query, sharer), fields)
+}
+
+// Search returns all bugs that match the given criteria
+func (bz *restBugzillaClient) Search(criteria Criteria, fields []string) (Bugs, error) {
+	return bz.SearchContext(context.Background(), criteria, fields)
+}
+
+// SearchContext returns all bugs that match the given criteria
+// Failures reported by bugzilla or the connection are returned as an *Error
+func (bz *restBugzillaClient) SearchContext(ctx context.Context, criteria Criteria, fields []string) (Bugs, error) {
+	return bz.search(ctx, criteria.params(), fields)
+}
+
+// search calls GET /rest/bug, which takes the same parameters as Bug.search
+// The bugs are requested a page at a time and merged together
+func (bz *restBugzillaClient) search(ctx context.Context, params searchParams, fields []string) (Bugs, error) {
+	a, err := requestAuth(bz.creds, &bz.token, func() (string, error) {
+		return bz.login(ctx)
+	})
+	if err != nil {
+		return Bugs{}, err
+	}
+
+	return fetchAll(bz.options.pageSize(), func(limit, offset int) (Bugs, error) {
+		values := url.Values{}
+		for name, value := range params {
+			switch v := value.(type) {
+			case string:
+				values.Set(name, v)
+			case []string:
+				values[name] = v
+			}
+		}
+		if len(fields) > 0 {
+			values.Set("include_fields", strings.Join(fields, ","))
+		}
+		values.Set("limit", strconv.Itoa(limit))
+		values.Set("offset", strconv.Itoa(offset))
+
+		var bugs Bugs
+		err := bz.get(ctx, "/bug", values, a, &bugs)
+		return bugs, err
+	})
+}
+
+// History returns the change history of the given bugs
+func (bz *restBugzillaClient) History(ids []int) ([]BugHistory, error) {
+	return bz.HistoryContext(context.Background(), ids)
+}
+
+// HistoryContext returns the change history of the given bugs using GET /rest/bug/{id}/history
+// Failures reported by bugzilla or the connection are returned as an *Error
+func (bz *restBugzillaClient) HistoryContext(ctx context.Context, ids []int) ([]BugHistory, error) {
+	a, err := requestAuth(bz.creds, &bz.token, func() (string, error) {
+		return bz.login(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var all []BugHistory
+	err = inBatches(ids, func(batch []int) error {
+		var result histories
+		err := bz.get(ctx, batchPath(batch, "history"), batchParams(batch), a, &result)
+		all = append(all, result.Bugs...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return all, nil
+}
+
+// Comments returns the comments on the given bugs
+func (bz *restBugzillaClient) Comments(ids []int, options CommentOptions) ([]BugComments, error) {
+	return bz.CommentsContext(context.Background(), ids, options)
+}
+
+// CommentsContext returns the comments on the given bugs using GET /rest/bug/{id}/comment
+// Failures reported by bugzilla or the connection are returned as an *Error
+func (bz *restBugzillaClient) CommentsContext(ctx context.Context, ids []int, options CommentOptions) ([]BugComments, error) {
+	a, err := requestAuth(bz.creds, &bz.token, func() (string, error) {
+		return bz.login(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var all []BugComments
+	err = inBatches(ids, func(batch []int) error {
+		params := batchParams(batch)
+		if fields := options.fields(); len(fields) > 0 {
+			params.Set("include_fields", strings.Join(fields, ","))
+		}
+
+		var result comments
+		err := bz.get(ctx, batchPath(batch, "comment"), params, a, &result)
+		all = append(all, result.list(batch)...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return all, nil
+}
+
+// batchPath returns the path for a method on a batch of bugs, ex) /bug/1/history
+// The first bug goes in the path and the rest are passed with batchParams
+func batchPath(batch []int, method string) string {
+	return "/bug/" + strconv.Itoa(batch[0]) + "/" + method
+}
+
+// batchParams returns the extra ids of a batch of bugs
+func batchParams(batch []int) url.Values {
+	values := url.Values{}
+	for _, id := range batch[1:] {
+		values.Add("ids", strconv.Itoa(id))
+	}
+	return values
+}
@@ -0,0 +1,95 @@
+package bugzilla
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Criteria describes a search directly rather than with a saved search
+// Each list matches bugs with any of the given values.  Empty lists are ignored.
+type Criteria struct {
+	Product       []string `yaml:"product"`
+	Component     []string `yaml:"component"`
+	Status        []string `yaml:"status"`
+	TargetRelease []string `yaml:"target_release"`
+	Keywords      []string `yaml:"keywords"`
+	// KeywordsType is how the keywords are matched: anywords (default), allwords, or nowords
+	KeywordsType string `yaml:"keywords_type"`
+	// Flags matches bugs with any of the given flags, ex) blocker+ or needinfo?
+	Flags []string `yaml:"flags"`
+	// Filters are extra conditions on any field, including custom (cf_*) fields
+	Filters []Filter `yaml:"filters"`
+}
+
+// Filter is a single advanced search condition
+type Filter struct {
+	// Field is the bugzilla field name, ex) cf_pm_score
+	Field string `yaml:"field"`
+	// Operator is a bugzilla search operator, ex) equals, substring, greaterthan, anyexact
+	Operator string `yaml:"operator"`
+	Value    string `yaml:"value"`
+}
+
+// searchParams are the parameters for Bug.search (or GET /rest/bug)
+// Values are either a string or a list of strings
+type searchParams map[string]interface{}
+
+// savedSearchParams returns the parameters to run a saved search
+func savedSearchParams(query, sharer string) searchParams {
+	return searchParams{
+		"savedsearch": query,
+		"sharer_id":   sharer,
+	}
+}
+
+// params translates the criteria into Bug.search parameters
+func (c Criteria) params() searchParams {
+	params := searchParams{}
+	lists := map[string][]string{
+		"product":        c.Product,
+		"component":      c.Component,
+		"status":         c.Status,
+		"target_release": c.TargetRelease,
+	}
+	for name, values := range lists {
+		if len(values) > 0 {
+			params[name] = values
+		}
+	}
+
+	if len(c.Keywords) > 0 {
+		params["keywords"] = strings.Join(c.Keywords, ",")
+		params["keywords_type"] = "anywords"
+		if c.KeywordsType != "" {
+			params["keywords_type"] = c.KeywordsType
+		}
+	}
+
+	// Flags and filters use the advanced search fields: f1, o1, v1, f2, o2, v2...
+	var filters []Filter
+	if len(c.Flags) > 0 {
+		filters = append(filters, Filter{Field: "flagtypes.name", Operator: "anyexact", Value: strings.Join(c.Flags, ",")})
+	}
+	filters = append(filters, c.Filters...)
+	for i, f := range filters {
+		params[fmt.Sprintf("f%d", i+1)] = f.Field
+		params[fmt.Sprintf("o%d", i+1)] = f.Operator
+		params[fmt.Sprintf("v%d", i+1)] = f.Value
+	}
+
+	return params
+}
+
+// Validate checks that the criteria will not match every bug
+func (c Criteria) Validate() error {
+	if len(c.Product) == 0 && len(c.Component) == 0 && len(c.Status) == 0 && len(c.TargetRelease) == 0 &&
+		len(c.Keywords) == 0 && len(c.Flags) == 0 && len(c.Filters) == 0 {
+		return fmt.Errorf("search criteria are empty")
+	}
+	for _, f := range c.Filters {
+		if f.Field == "" || f.Operator == "" {
+			return fmt.Errorf("search filter %+v needs a field and an operator", f)
+		}
+	}
+	return nil
+}
@@ -1,6 +1,8 @@
 package bugzilla
 
 import (
+	"database/sql"
+	"database/sql/driver"
 	"encoding/json"
 	"fmt"
 	"strconv"
@@ -9,29 +11,192 @@ import (
 	"github.com/lib/pq"
 )
 
+// Source is the name stored with bugs that came from bugzilla
+const Source = "bugzilla"
+
 // ExternalID is the specific ID for "Red Hat Customer Portal"
+// It is the default customer portal tracker if none are configured
 const ExternalID = 60
 
-// SingleElemSlice deals with the special case where an [1]array is returned
-type SingleElemSlice string
-
-// MarshalJSON is a wrapper that converts a string to a single element slice
-func (s SingleElemSlice) MarshalJSON() ([]byte, error) {
-	return json.Marshal([]string{string(s)})
-}
+// MultiValue is a list of strings that bugzilla may return as either a list or a single string
+// It is stored in the database as a text[]
+type MultiValue []string
 
-// UnmarshalJSON is a wrapper that pulls the string from a single element slice
-func (s *SingleElemSlice) UnmarshalJSON(b []byte) error {
+// UnmarshalJSON accepts either a list of strings or a single string
+func (m *MultiValue) UnmarshalJSON(b []byte) error {
 	var sl []string
 	err := json.Unmarshal(b, &sl)
+	if err == nil {
+		*m = MultiValue(sl)
+		return nil
+	}
+
+	var str string
+	err = json.Unmarshal(b, &str)
+	if err != nil {
+		return fmt.Errorf("expected a string or a list of strings, got %s", string(b))
+	}
+	*m = MultiValue{str}
+	return nil
+}
+
+// Primary returns the first value, or an empty string if there are none
+func (m MultiValue) Primary() string {
+	if len(m) == 0 {
+		return ""
+	}
+	return m[0]
+}
+
+// Value converts the list to a postgresql array
+// A nil list is stored as an empty array rather than NULL
+func (m MultiValue) Value() (driver.Value, error) {
+	if m == nil {
+		return "{}", nil
+	}
+	return pq.StringArray(m).Value()
+}
+
+// Scan reads a postgresql array into the list
+func (m *MultiValue) Scan(src interface{}) error {
+	var sa pq.StringArray
+	err := sa.Scan(src)
+	if err != nil {
+		return err
+	}
+	*m = MultiValue(sa)
+	return nil
+}
+
+// Flags is the list of a bug's flags as name and status, ex) blocker+ or needinfo?
+// It is stored in the database as a text[]
+type Flags []string
+
+// flag is the part of a bugzilla flag object that we keep
+type flag struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+// MarshalJSON is a wrapper that converts the flags back to a list of flag objects
+func (f Flags) MarshalJSON() ([]byte, error) {
+	flags := make([]flag, len(f))
+	for i, name := range f {
+		// The status is always the last character
+		if len(name) > 0 {
+			flags[i] = flag{Name: name[:len(name)-1], Status: name[len(name)-1:]}
+		}
+	}
+	return json.Marshal(flags)
+}
+
+// UnmarshalJSON is a wrapper that converts a list of flag objects into name and status strings
+func (f *Flags) UnmarshalJSON(b []byte) error {
+	var flags []flag
+	err := json.Unmarshal(b, &flags)
+	if err != nil {
+		return err
+	}
+	*f = make(Flags, len(flags))
+	for i, fl := range flags {
+		(*f)[i] = fl.Name + fl.Status
+	}
+	return nil
+}
+
+// Value converts the flags to a postgresql array
+// A nil list is stored as an empty array rather than NULL
+func (f Flags) Value() (driver.Value, error) {
+	if f == nil {
+		return "{}", nil
+	}
+	return pq.StringArray(f).Value()
+}
+
+// Scan reads a postgresql array into the flags
+func (f *Flags) Scan(src interface{}) error {
+	var sa pq.StringArray
+	err := sa.Scan(src)
+	if err != nil {
+		return err
+	}
+	*f = Flags(sa)
+	return nil
+}
+
+// BugID is a reference to another bug, where 0 means none
+// Bugzilla may return it as a number, a string, or null
+type BugID int
+
+// UnmarshalJSON accepts a number, a string of a number, an empty string, or null
+func (id *BugID) UnmarshalJSON(b []byte) error {
+	var i int
+	if err := json.Unmarshal(b, &i); err == nil {
+		*id = BugID(i)
+		return nil
+	}
+
+	var str *string
+	err := json.Unmarshal(b, &str)
+	if err != nil {
+		return fmt.Errorf("expected a bug id, got %s", string(b))
+	}
+	if str == nil || *str == "" {
+		*id = 0
+		return nil
+	}
+	i, err = strconv.Atoi(*str)
+	if err != nil {
+		return fmt.Errorf("expected a bug id, got %s", string(b))
+	}
+	*id = BugID(i)
+	return nil
+}
+
+// Value stores the id, using NULL for none
+func (id BugID) Value() (driver.Value, error) {
+	if id == 0 {
+		return nil, nil
+	}
+	return int64(id), nil
+}
+
+// Scan reads a nullable id from the database
+func (id *BugID) Scan(src interface{}) error {
+	var i sql.NullInt64
+	err := i.Scan(src)
 	if err != nil {
 		return err
 	}
-	if len(sl) != 1 {
-		return fmt.Errorf("expected len 1 for Single Element Slice, got %d", len(sl))
+	*id = BugID(i.Int64)
+	return nil
+}
+
+// BugIDs is a list of references to other bugs
+// It is stored in the database as an integer[]
+type BugIDs []int
+
+// Value converts the list to a postgresql array
+// A nil list is stored as an empty array rather than NULL
+func (ids BugIDs) Value() (driver.Value, error) {
+	a := make(pq.Int64Array, len(ids))
+	for i, id := range ids {
+		a[i] = int64(id)
 	}
+	return a.Value()
+}
 
-	*s = SingleElemSlice(sl[0])
+// Scan reads a postgresql array into the list
+func (ids *BugIDs) Scan(src interface{}) error {
+	var a pq.Int64Array
+	err := a.Scan(src)
+	if err != nil {
+		return err
+	}
+	*ids = make(BugIDs, len(a))
+	for i, id := range a {
+		(*ids)[i] = int(id)
+	}
 	return nil
 }
 
@@ -40,7 +205,7 @@ type Score int
 
 // MarshalJSON is a wrapper that converts an int to a string
 func (s Score) MarshalJSON() ([]byte, error) {
-	return json.Marshal(string(s))
+	return json.Marshal(strconv.Itoa(int(s)))
 }
 
 // UnmarshalJSON is a wrapper that converts a string to an integer
@@ -60,17 +225,76 @@ func (s *Score) UnmarshalJSON(b []byte) error {
 
 // Bug maps to the desired fields of a bugzilla bug
 type Bug struct {
-	ID            int             `json:"id"`
-	Component     SingleElemSlice `json:"component"`
-	TargetRelease SingleElemSlice `json:"target_release"`
-	AssignedTo    string          `json:"assigned_to"`
-	Status        string          `json:"status"`
-	Keywords      pq.StringArray  `json:"keywords"`
-	PmScore       Score           `json:"cf_pm_score"`
-	Summary       string          `json:"summary"`
-	Externals     json.RawMessage `json:"external_bugs"`
-	DateStamp     time.Time
-	Age           int
+	ID             int            `json:"id"`
+	Component      MultiValue     `json:"component"`
+	TargetRelease  MultiValue     `json:"target_release"`
+	AssignedTo     string         `json:"assigned_to"`
+	Status         string         `json:"status"`
+	Keywords       pq.StringArray `json:"keywords"`
+	PmScore        Score          `json:"cf_pm_score"`
+	Summary        string         `json:"summary"`
+	Externals      ExternalBugs   `json:"external_bugs"`
+	Severity       string         `json:"severity"`
+	Priority       string         `json:"priority"`
+	Reporter       string         `json:"creator"`
+	CreationTime   time.Time      `json:"creation_time"`
+	LastChangeTime time.Time      `json:"last_change_time"`
+	Resolution     string         `json:"resolution"`
+	Whiteboard     string         `json:"whiteboard"`
+	Flags          Flags          `json:"flags"`
+	DependsOn      BugIDs         `json:"depends_on"`
+	Blocks         BugIDs         `json:"blocks"`
+	DupeOf         BugID          `json:"dupe_of"`
+	CloneOf        BugID          `json:"cf_clone_of"`
+	// Raw is the complete bug as returned by bugzilla, including fields not listed here
+	Raw json.RawMessage `json:"-"`
+	// Comments is filled in from Bug.comments rather than the search
+	Comments  CommentSummary `json:"-"`
+	DateStamp time.Time
+	Age       int
+	// QueryName is the snapshot query that found the bug
+	QueryName string
+	// Source is the tracker the bug came from, ex) jira.  Empty means bugzilla.
+	Source string `json:"-"`
+}
+
+// UnmarshalJSON decodes the bug fields and keeps a copy of the raw bug
+func (b *Bug) UnmarshalJSON(data []byte) error {
+	// bug has the same fields but not this method, so it won't recurse
+	type bug Bug
+	var decoded bug
+	err := json.Unmarshal(data, &decoded)
+	if err != nil {
+		return err
+	}
+	*b = Bug(decoded)
+	b.Raw = append(json.RawMessage(nil), data...)
+	return nil
+}
+
+// Field returns the raw json of any field in the bug, ex) a custom cf_* field
+// Returns false if the field wasn't returned by bugzilla
+func (b Bug) Field(name string) (json.RawMessage, bool) {
+	if len(b.Raw) == 0 {
+		return nil, false
+	}
+	var fields map[string]json.RawMessage
+	err := json.Unmarshal(b.Raw, &fields)
+	if err != nil {
+		return nil, false
+	}
+	value, ok := fields[name]
+	return value, ok
+}
+
+// PrimaryComponent is the bug's first component, for consumers that expect a single component
+func (b Bug) PrimaryComponent() string {
+	return b.Component.Primary()
+}
+
+// PrimaryTargetRelease is the bug's first target release, for consumers that expect a single release
+func (b Bug) PrimaryTargetRelease() string {
+	return b.TargetRelease.Primary()
 }
 
 // Bugs is a list of, well, bugs
@@ -0,0 +1,130 @@
+package bugzilla
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestMultiValueUnmarshal(t *testing.T) {
+	tests := []struct {
+		json     string
+		expected MultiValue
+		primary  string
+	}{
+		{json: `["foo"]`, expected: MultiValue{"foo"}, primary: "foo"},
+		{json: `["foo", "bar"]`, expected: MultiValue{"foo", "bar"}, primary: "foo"},
+		{json: `"foo"`, expected: MultiValue{"foo"}, primary: "foo"},
+		{json: `[]`, expected: MultiValue{}, primary: ""},
+	}
+
+	for _, test := range tests {
+		var m MultiValue
+		err := json.Unmarshal([]byte(test.json), &m)
+		if err != nil {
+			t.Errorf("%s: unexpected err: %v", test.json, err)
+			continue
+		}
+		if !reflect.DeepEqual(m, test.expected) {
+			t.Errorf("%s: expected %#v, got %#v", test.json, test.expected, m)
+		}
+		if m.Primary() != test.primary {
+			t.Errorf("%s: expected primary %q, got %q", test.json, test.primary, m.Primary())
+		}
+	}
+
+	var m MultiValue
+	if err := json.Unmarshal([]byte(`{"name": "foo"}`), &m); err == nil {
+		t.Errorf("expected an error for an object, got %#v", m)
+	}
+}
+
+func TestFlagsUnmarshal(t *testing.T) {
+	var f Flags
+	err := json.Unmarshal([]byte(`[{"name": "blocker", "status": "+", "setter": "foo@example.com"}, {"name": "needinfo", "status": "?"}]`), &f)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	expected := Flags{"blocker+", "needinfo?"}
+	if !reflect.DeepEqual(f, expected) {
+		t.Errorf("expected %#v, got %#v", expected, f)
+	}
+}
+
+func TestBugRawFields(t *testing.T) {
+	var b Bug
+	err := json.Unmarshal([]byte(`{"id": 1, "summary": "foo", "cf_devel_whiteboard": "bar"}`), &b)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if b.ID != 1 || b.Summary != "foo" {
+		t.Errorf("expected the known fields to be decoded, got %+v", b)
+	}
+
+	value, ok := b.Field("cf_devel_whiteboard")
+	if !ok || string(value) != `"bar"` {
+		t.Errorf("expected custom field %q, got %q (found %v)", `"bar"`, string(value), ok)
+	}
+	if _, ok := b.Field("cf_missing"); ok {
+		t.Errorf("expected a missing field not to be found")
+	}
+}
+
+func TestExternalBugs(t *testing.T) {
+	var e ExternalBugs
+	err := json.Unmarshal([]byte(`[
+		{"ext_bz_id": 60, "ext_bz_bug_id": "01234567", "ext_status": "Waiting on Red Hat", "ext_priority": "High", "type": {"id": 60, "description": "Red Hat Customer Portal"}},
+		{"ext_bz_bug_id": 42, "type": {"id": 131, "description": "Github"}}
+	]`), &e)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	expected := ExternalBugs{
+		{TrackerID: 60, TrackerName: "Red Hat Customer Portal", ExternalID: "01234567", Status: "Waiting on Red Hat", Priority: "High"},
+		{TrackerID: 131, TrackerName: "Github", ExternalID: "42"},
+	}
+	if !reflect.DeepEqual(e, expected) {
+		t.Errorf("expected %#v, got %#v", expected, e)
+	}
+	if !e.InTrackers([]int{ExternalID}) || e.InTrackers([]int{1}) {
+		t.Errorf("unexpected trackers for %#v", e)
+	}
+
+	// Stored external bugs are read back the same way
+	value, err := e.Value()
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	var scanned ExternalBugs
+	err = scanned.Scan([]byte(value.(string)))
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if !reflect.DeepEqual(scanned, expected) {
+		t.Errorf("expected %#v after a round trip, got %#v", expected, scanned)
+	}
+}
+
+func TestBugIDUnmarshal(t *testing.T) {
+	tests := []struct {
+		json     string
+		expected BugID
+	}{
+		{json: `123`, expected: 123},
+		{json: `"123"`, expected: 123},
+		{json: `""`, expected: 0},
+		{json: `null`, expected: 0},
+	}
+
+	for _, test := range tests {
+		var id BugID
+		err := json.Unmarshal([]byte(test.json), &id)
+		if err != nil {
+			t.Errorf("%s: unexpected err: %v", test.json, err)
+			continue
+		}
+		if id != test.expected {
+			t.Errorf("%s: expected %d, got %d", test.json, test.expected, id)
+		}
+	}
+}
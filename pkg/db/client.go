@@ -5,17 +5,26 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/lib/pq"
 
 	"github.com/thrasher-redhat/internal-tools/pkg/bugzilla"
+	"github.com/thrasher-redhat/internal-tools/pkg/github"
+	"github.com/thrasher-redhat/internal-tools/pkg/trello"
 )
 
 // WriteClient knows how to write to the database
 type WriteClient interface {
-	SnapshotBugzilla(bugzilla.Bugs) error
-	//SnapshotTrello() will be here in the future
+	SnapshotBugzilla(string, bugzilla.Bugs, SnapshotRun) error
+	StoreSnapshotRun(SnapshotRun) error
+	QuarantineBugzilla(string, bugzilla.Bugs, SnapshotRun) error
+	CompactHourlySnapshots(time.Time) (int, error)
+	StoreHistory([]bugzilla.BugHistory) error
+	SnapshotTrello(string, []trello.Card) error
+	SnapshotGitHub(string, []github.Issue) error
+	StoreGitHubIssues([]github.Issue) error
 }
 
 // ReadClient knows how to query the database (read-only)
@@ -24,14 +33,25 @@ type ReadClient interface {
 	GetEarliest() (time.Time, error)
 	GetPreviousDate(string) (time.Time, error)
 	GetEarliestDateForTargets([]string) (time.Time, error)
-	GetBreakdown(string, string, []string, []string, bool, []string) (Breakdown, error)
-	GetBugs(string, []string) ([]bugzilla.Bug, error)
+	GetBreakdown(string, string, BugFilter) (Breakdown, error)
+	GetBugs(string, BugFilter) ([]bugzilla.Bug, error)
+	GetHistoryFetchTimes([]int) (map[int]time.Time, error)
+	GetHistory(int) ([]BugChange, error)
+	GetCards(string, string) ([]trello.Card, error)
+	GetCardsForBug(int, string) ([]trello.Card, error)
+	GetCardDates(string) ([]time.Time, error)
+	GetListFlow(string, string, string) ([]ListFlow, error)
+	GetGitHubIssues(string, string) ([]github.Issue, error)
+	GetGitHubIssue(string, int, string) (*github.Issue, error)
+	GetSnapshotRuns(int) ([]SnapshotRun, error)
+	GetPreviousComponentCounts(string) (map[string]int, error)
 }
 
 // Client knows how to connect and interact with the database
 type Client interface {
 	ReadClient
 	WriteClient
+	MigrationClient
 	Close()
 }
 
@@ -58,25 +78,58 @@ func (c postgresClient) Close() {
 	c.database.Close()
 }
 
-// clearBugs will remove all bugs with the given datestamp
-func clearBugs(tx *sql.Tx, t time.Time) error {
+// clearBugs will remove all bugs from the named query with the given datestamp
+// Returns the number of bugs removed
+func clearBugs(tx *sql.Tx, queryName string, t time.Time) (int, error) {
 	// Delete all bugs with given datestamp
-	result, err := tx.Exec(`DELETE FROM bugs WHERE datestamp = ($1)`, t)
+	result, err := tx.Exec(`DELETE FROM bugs WHERE datestamp = ($1) AND query_name = ($2)`, t, queryName)
 	if err != nil {
-		return fmt.Errorf("unable to delete bugs with date %v: %v", t, err)
+		return 0, fmt.Errorf("unable to delete bugs with date %v for query %q: %v", t, queryName, err)
 	}
 	total, err := result.RowsAffected()
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	log.Printf("Removed %d bugs for date: %v\n", total, t.Format("2006-01-02"))
-	return nil
+	log.Printf("Removed %d bugs from query %q for date: %v\n", total, queryName, t.Format("2006-01-02"))
+	return int(total), nil
+}
+
+// nullTime converts a zero time (the field wasn't returned by bugzilla) into a NULL
+func nullTime(t time.Time) interface{} {
+	if t.IsZero() {
+		return nil
+	}
+	return t
+}
+
+// stringArray converts a list to a postgresql array, using an empty array rather than NULL for a nil list
+func stringArray(list []string) interface{} {
+	if list == nil {
+		return "{}"
+	}
+	return pq.Array(list)
+}
+
+// rawJSON returns the bug's raw json, using an empty object if there is none
+func rawJSON(b bugzilla.Bug) string {
+	if len(b.Raw) == 0 {
+		return "{}"
+	}
+	return string(b.Raw)
+}
+
+// source returns the tracker the bug came from, defaulting to bugzilla
+func source(b bugzilla.Bug) string {
+	if b.Source == "" {
+		return bugzilla.Source
+	}
+	return b.Source
 }
 
 // insertBug processes and inserts (via a copy statement) a bug into the database
-// Bugs are inserted with today's date
-func insertBug(stmt *sql.Stmt, b bugzilla.Bug) error {
+// Bugs are inserted with today's date and the name of the query that found them
+func insertBug(stmt *sql.Stmt, queryName string, b bugzilla.Bug) error {
 	// TODO - Look into reflection or gogenerate
 	_, err := stmt.Exec(
 		b.ID,
@@ -87,8 +140,26 @@ func insertBug(stmt *sql.Stmt, b bugzilla.Bug) error {
 		b.Summary,
 		pq.Array(b.Keywords),
 		b.PmScore,
-		string(b.Externals),
+		b.Externals,
 		time.Now(),
+		queryName,
+		b.Severity,
+		b.Priority,
+		b.Reporter,
+		nullTime(b.CreationTime),
+		nullTime(b.LastChangeTime),
+		b.Resolution,
+		b.Whiteboard,
+		b.Flags,
+		rawJSON(b),
+		b.Comments.Count,
+		nullTime(b.Comments.LastCommentTime),
+		b.Comments.LastCommenter,
+		b.DependsOn,
+		b.Blocks,
+		b.DupeOf,
+		b.CloneOf,
+		source(b),
 	)
 	if err != nil {
 		return fmt.Errorf("unable to insert bug with id %d: %v", b.ID, err)
@@ -97,22 +168,43 @@ func insertBug(stmt *sql.Stmt, b bugzilla.Bug) error {
 	return nil
 }
 
+// bugColumns are the columns written for each bug, in the order that insertBug gives them
+var bugColumns = []string{
+	"id",
+	"component",
+	"target_release",
+	"assigned_to",
+	"status",
+	"summary",
+	"keywords",
+	"cf_pm_score",
+	"externals",
+	"datestamp",
+	"query_name",
+	"severity",
+	"priority",
+	"reporter",
+	"creation_time",
+	"last_change_time",
+	"resolution",
+	"whiteboard",
+	"flags",
+	"raw",
+	"comment_count",
+	"last_comment_time",
+	"last_commenter",
+	"depends_on",
+	"blocks",
+	"dupe_of",
+	"clone_of",
+	"source",
+}
+
 // StoreBugs preps and stores all provided bugs in the given transaction
-func storeBugs(tx *sql.Tx, bugs bugzilla.Bugs) error {
+func storeBugs(tx *sql.Tx, queryName string, bugs bugzilla.Bugs) error {
 	// Copy is faster than insert for mass inserts like this
 	// Similar to `INSERT INTO bugs(...) VALUES(...);` but faster under the hood
-	stmt, err := tx.Prepare(pq.CopyIn("bugs",
-		"id",
-		"component",
-		"target_release",
-		"assigned_to",
-		"status",
-		"summary",
-		"keywords",
-		"cf_pm_score",
-		"externals",
-		"datestamp",
-	))
+	stmt, err := tx.Prepare(pq.CopyIn("bugs", bugColumns...))
 	if err != nil {
 		return err
 	}
@@ -120,7 +212,7 @@ func storeBugs(tx *sql.Tx, bugs bugzilla.Bugs) error {
 
 	// Insert each bug
 	for _, bug := range bugs.Bugs {
-		err = insertBug(stmt, bug)
+		err = insertBug(stmt, queryName, bug)
 
 		if err != nil {
 			return err
@@ -136,8 +228,9 @@ func storeBugs(tx *sql.Tx, bugs bugzilla.Bugs) error {
 	return nil
 }
 
-// SnapshotBugzilla removes today's bugs (if any) and stores the new bugs in a single transaction
-func (c postgresClient) SnapshotBugzilla(bugs bugzilla.Bugs) error {
+// SnapshotBugzilla removes today's bugs (if any) for the named query and stores the new bugs in a single transaction
+// The run is recorded in the same transaction, with its query name, bug counts, and finish time filled in
+func (c postgresClient) SnapshotBugzilla(queryName string, bugs bugzilla.Bugs, run SnapshotRun) error {
 	// Setup transaction to remove today's bugs AND insert new bugs for today
 	tx, err := c.database.Begin()
 	if err != nil {
@@ -146,19 +239,517 @@ func (c postgresClient) SnapshotBugzilla(bugs bugzilla.Bugs) error {
 	defer tx.Rollback()
 
 	// Clear today's (old) bugs, if any
-	err = clearBugs(tx, time.Now())
+	removed, err := clearBugs(tx, queryName, time.Now())
 	if err != nil {
 		log.Println("Error clearing bugs - rolling back snapshot process")
 		return err
 	}
 
 	// Add today's (new) bugs
-	err = storeBugs(tx, bugs)
+	err = storeBugs(tx, queryName, bugs)
 	if err != nil {
 		log.Println("Error storing bugs - rolling back snapshot process")
 		return err
 	}
 
+	// Record what changed since the query's previous snapshot
+	err = storeEvents(tx, queryName, bugs)
+	if err != nil {
+		log.Println("Error storing bug events - rolling back snapshot process")
+		return err
+	}
+
+	// Keep a copy of this run's bugs as well, as the next run will replace today's
+	if run.Hourly {
+		err = storeHourlyBugs(tx, queryName, run.StartedAt)
+		if err != nil {
+			log.Println("Error storing hourly bugs - rolling back snapshot process")
+			return err
+		}
+	}
+
+	run.QueryName = queryName
+	run.BugCount = len(bugs.Bugs)
+	run.Removed = removed
+	run.FinishedAt = time.Now()
+	_, err = storeSnapshotRun(tx, run)
+	if err != nil {
+		log.Println("Error storing snapshot run - rolling back snapshot process")
+		return err
+	}
+
+	log.Println("Commiting transaction")
+	return tx.Commit()
+}
+
+// hourlyColumns are the columns of bugs that are kept in the hourly snapshots
+// These are listed separately from bugColumns so that a column added to bugs doesn't break the hourly copy
+var hourlyColumns = []string{
+	"id",
+	"component",
+	"target_release",
+	"assigned_to",
+	"status",
+	"summary",
+	"keywords",
+	"cf_pm_score",
+	"externals",
+	"datestamp",
+	"query_name",
+	"severity",
+	"priority",
+	"reporter",
+	"creation_time",
+	"last_change_time",
+	"resolution",
+	"whiteboard",
+	"flags",
+	"raw",
+	"comment_count",
+	"last_comment_time",
+	"last_commenter",
+	"depends_on",
+	"blocks",
+	"dupe_of",
+	"clone_of",
+	"source",
+}
+
+// storeHourlyBugs copies the query's bugs that were just stored for today into the hourly snapshots under the given time
+func storeHourlyBugs(tx *sql.Tx, queryName string, t time.Time) error {
+	columns := strings.Join(hourlyColumns, ", ")
+	_, err := tx.Exec("INSERT INTO bug_snapshots_hourly ("+columns+", snapshot_time) SELECT "+columns+", $1 FROM bugs WHERE datestamp = $2 AND query_name = $3", t, time.Now(), queryName)
+	if err != nil {
+		return fmt.Errorf("unable to store hourly bugs for query %q: %v", queryName, err)
+	}
+	return nil
+}
+
+// CompactHourlySnapshots removes the hourly snapshots from before the given date
+// The last hourly snapshot of a day is kept as the daily snapshot if the day doesn't already have one
+func (c postgresClient) CompactHourlySnapshots(before time.Time) (int, error) {
+	tx, err := c.database.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	columns := strings.Join(hourlyColumns, ", ")
+	_, err = tx.Exec("INSERT INTO bugs ("+columns+") SELECT "+columns+" FROM bug_snapshots_hourly AS hourly WHERE datestamp < $1 "+
+		"AND (query_name, snapshot_time) IN (SELECT query_name, MAX(snapshot_time) FROM bug_snapshots_hourly WHERE datestamp < $1 GROUP BY datestamp, query_name) "+
+		"AND NOT EXISTS (SELECT 1 FROM bugs WHERE bugs.datestamp = hourly.datestamp AND bugs.query_name = hourly.query_name)", before)
+	if err != nil {
+		return 0, fmt.Errorf("unable to keep the last hourly snapshots as daily snapshots: %v", err)
+	}
+
+	result, err := tx.Exec("DELETE FROM bug_snapshots_hourly WHERE datestamp < $1", before)
+	if err != nil {
+		return 0, fmt.Errorf("unable to delete hourly snapshots from before %v: %v", before.Format("2006-01-02"), err)
+	}
+	total, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(total), tx.Commit()
+}
+
+// storeSnapshotRun records a run of a snapshot query and returns the run's id
+func storeSnapshotRun(tx *sql.Tx, run SnapshotRun) (int, error) {
+	if run.Source == "" {
+		run.Source = bugzilla.Source
+	}
+	var id int
+	err := tx.QueryRow(`INSERT INTO snapshot_runs (query_name, source, started_at, finished_at, fetch_seconds, bug_count, removed_count, error, quarantined) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id`,
+		run.QueryName,
+		run.Source,
+		run.StartedAt,
+		run.FinishedAt,
+		run.FetchDuration.Seconds(),
+		run.BugCount,
+		run.Removed,
+		run.Error,
+		run.Quarantined,
+	).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("unable to store run of query %q: %v", run.QueryName, err)
+	}
+	return id, nil
+}
+
+// StoreSnapshotRun records a run of a snapshot query on its own, such as one that failed before any bugs were stored
+func (c postgresClient) StoreSnapshotRun(run SnapshotRun) error {
+	tx, err := c.database.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = storeSnapshotRun(tx, run)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// QuarantineBugzilla records a run whose bugs were held back and stores the bugs aside for review
+// Today's bugs for the query are left alone.  The run should give the reason it was quarantined as its error.
+func (c postgresClient) QuarantineBugzilla(queryName string, bugs bugzilla.Bugs, run SnapshotRun) error {
+	tx, err := c.database.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	run.QueryName = queryName
+	run.Quarantined = true
+	run.FinishedAt = time.Now()
+	id, err := storeSnapshotRun(tx, run)
+	if err != nil {
+		return err
+	}
+
+	stmt, err := tx.Prepare(pq.CopyIn("quarantined_bugs", "run_id", "id", "source", "component", "raw"))
+	if err != nil {
+		return fmt.Errorf("unable to prepare quarantine statement: %v", err)
+	}
+	for _, b := range bugs.Bugs {
+		_, err = stmt.Exec(id, b.ID, source(b), pq.Array(b.Component), rawJSON(b))
+		if err != nil {
+			return fmt.Errorf("unable to quarantine bug %d: %v", b.ID, err)
+		}
+	}
+	_, err = stmt.Exec()
+	if err != nil {
+		return fmt.Errorf("unable to flush quarantined bugs: %v", err)
+	}
+	err = stmt.Close()
+	if err != nil {
+		return fmt.Errorf("unable to close quarantine statement: %v", err)
+	}
+
+	log.Printf("Quarantined %d bugs from query %q as run %d\n", len(bugs.Bugs), queryName, id)
+	return tx.Commit()
+}
+
+// clearHistory removes the stored changes for the given bugs
+func clearHistory(tx *sql.Tx, ids []int) error {
+	_, err := tx.Exec(`DELETE FROM bug_changes WHERE bug_id = ANY($1)`, pq.Array(ids))
+	if err != nil {
+		return fmt.Errorf("unable to delete changes for %d bugs: %v", len(ids), err)
+	}
+	return nil
+}
+
+// storeChanges flattens the histories and stores each change in the given transaction, along with when they were fetched
+func storeChanges(tx *sql.Tx, histories []bugzilla.BugHistory, fetchedAt time.Time) error {
+	stmt, err := tx.Prepare(pq.CopyIn("bug_changes",
+		"bug_id",
+		"change_time",
+		"who",
+		"field_name",
+		"removed",
+		"added",
+		"fetched_at",
+	))
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, h := range histories {
+		for _, entry := range h.History {
+			for _, change := range entry.Changes {
+				_, err = stmt.Exec(h.ID, entry.When, entry.Who, change.FieldName, change.Removed, change.Added, fetchedAt)
+				if err != nil {
+					return fmt.Errorf("unable to insert change for bug with id %d: %v", h.ID, err)
+				}
+			}
+		}
+	}
+
+	// Flushing buffered data
+	_, err = stmt.Exec()
+	return err
+}
+
+// StoreHistory replaces the stored changes for each of the given bugs in a single transaction
+// Bugzilla always returns a bug's full history, so the old changes are removed rather than merged
+func (c postgresClient) StoreHistory(histories []bugzilla.BugHistory) error {
+	if len(histories) == 0 {
+		return nil
+	}
+
+	tx, err := c.database.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	ids := make([]int, len(histories))
+	for i, h := range histories {
+		ids[i] = h.ID
+	}
+
+	err = clearHistory(tx, ids)
+	if err != nil {
+		log.Println("Error clearing bug changes - rolling back history")
+		return err
+	}
+
+	err = storeChanges(tx, histories, time.Now())
+	if err != nil {
+		log.Println("Error storing bug changes - rolling back history")
+		return err
+	}
+
+	log.Printf("Commiting history for %d bugs\n", len(histories))
+	return tx.Commit()
+}
+
+// clearCards will remove all cards (and their links to bugs) from the named board with the given datestamp
+func clearCards(tx *sql.Tx, board string, t time.Time) error {
+	_, err := tx.Exec(`DELETE FROM card_bugs WHERE datestamp = ($1) AND board = ($2)`, t, board)
+	if err != nil {
+		return fmt.Errorf("unable to delete card links with date %v for board %q: %v", t, board, err)
+	}
+
+	result, err := tx.Exec(`DELETE FROM cards WHERE datestamp = ($1) AND board = ($2)`, t, board)
+	if err != nil {
+		return fmt.Errorf("unable to delete cards with date %v for board %q: %v", t, board, err)
+	}
+	total, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Removed %d cards from board %q for date: %v\n", total, board, t.Format("2006-01-02"))
+	return nil
+}
+
+// storeCards preps and stores all provided cards in the given transaction
+// Cards are inserted with today's date and the name of the board they are on
+func storeCards(tx *sql.Tx, board string, cards []trello.Card) error {
+	stmt, err := tx.Prepare(pq.CopyIn("cards",
+		"id",
+		"board",
+		"name",
+		"list",
+		"url",
+		"datestamp",
+		"labels",
+		"members",
+		"due",
+		"last_activity",
+		"closed",
+	))
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	now := time.Now()
+	for _, card := range cards {
+		_, err = stmt.Exec(
+			card.ID,
+			board,
+			card.Name,
+			card.List,
+			card.URL,
+			now,
+			stringArray(card.Labels),
+			stringArray(card.Members),
+			nullTime(card.Due),
+			nullTime(card.LastActivity),
+			card.Closed,
+		)
+		if err != nil {
+			return fmt.Errorf("unable to insert card with id %q: %v", card.ID, err)
+		}
+	}
+
+	// Flushing buffered data
+	_, err = stmt.Exec()
+	return err
+}
+
+// storeCardBugs stores the links between the cards and the bugs they reference in the given transaction
+func storeCardBugs(tx *sql.Tx, board string, cards []trello.Card) error {
+	stmt, err := tx.Prepare(pq.CopyIn("card_bugs",
+		"card_id",
+		"board",
+		"bug_id",
+		"datestamp",
+	))
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	now := time.Now()
+	for _, card := range cards {
+		for _, id := range card.Bugs {
+			_, err = stmt.Exec(card.ID, board, id, now)
+			if err != nil {
+				return fmt.Errorf("unable to link card with id %q to bug %d: %v", card.ID, id, err)
+			}
+		}
+	}
+
+	// Flushing buffered data
+	_, err = stmt.Exec()
+	return err
+}
+
+// SnapshotTrello removes today's cards (if any) for the named board and stores the new cards in a single transaction
+func (c postgresClient) SnapshotTrello(board string, cards []trello.Card) error {
+	tx, err := c.database.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Clear today's (old) cards, if any
+	err = clearCards(tx, board, time.Now())
+	if err != nil {
+		log.Println("Error clearing cards - rolling back snapshot process")
+		return err
+	}
+
+	// Add today's (new) cards
+	err = storeCards(tx, board, cards)
+	if err != nil {
+		log.Println("Error storing cards - rolling back snapshot process")
+		return err
+	}
+
+	err = storeCardBugs(tx, board, cards)
+	if err != nil {
+		log.Println("Error storing card links - rolling back snapshot process")
+		return err
+	}
+
 	log.Println("Commiting transaction")
 	return tx.Commit()
 }
+
+// clearGitHubRepo will remove all issues in the given repository with the given datestamp
+func clearGitHubRepo(tx *sql.Tx, repo string, t time.Time) error {
+	result, err := tx.Exec(`DELETE FROM github_issues WHERE datestamp = ($1) AND repo = ($2)`, t, repo)
+	if err != nil {
+		return fmt.Errorf("unable to delete github issues with date %v for repo %q: %v", t, repo, err)
+	}
+	total, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Removed %d github issues from repo %q for date: %v\n", total, repo, t.Format("2006-01-02"))
+	return nil
+}
+
+// storeGitHubIssues preps and stores all provided issues in the given transaction
+// Issues are inserted with today's date
+func storeGitHubIssues(tx *sql.Tx, issues []github.Issue) error {
+	stmt, err := tx.Prepare(pq.CopyIn("github_issues",
+		"repo",
+		"number",
+		"datestamp",
+		"title",
+		"state",
+		"url",
+		"pull_request",
+		"merged_at",
+		"labels",
+		"milestone",
+		"assignees",
+		"author",
+		"created_at",
+		"updated_at",
+		"closed_at",
+	))
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	now := time.Now()
+	for _, issue := range issues {
+		_, err = stmt.Exec(
+			issue.Repo,
+			issue.Number,
+			now,
+			issue.Title,
+			issue.State,
+			issue.URL,
+			issue.PullRequest,
+			nullTime(issue.MergedAt),
+			stringArray(issue.Labels),
+			issue.Milestone,
+			stringArray(issue.Assignees),
+			issue.Author,
+			nullTime(issue.CreatedAt),
+			nullTime(issue.UpdatedAt),
+			nullTime(issue.ClosedAt),
+		)
+		if err != nil {
+			return fmt.Errorf("unable to insert github issue %s#%d: %v", issue.Repo, issue.Number, err)
+		}
+	}
+
+	// Flushing buffered data
+	_, err = stmt.Exec()
+	return err
+}
+
+// SnapshotGitHub removes today's issues (if any) for the repository and stores the new issues in a single transaction
+func (c postgresClient) SnapshotGitHub(repo string, issues []github.Issue) error {
+	tx, err := c.database.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Clear today's (old) issues, if any
+	err = clearGitHubRepo(tx, repo, time.Now())
+	if err != nil {
+		log.Println("Error clearing github issues - rolling back snapshot process")
+		return err
+	}
+
+	// Add today's (new) issues
+	err = storeGitHubIssues(tx, issues)
+	if err != nil {
+		log.Println("Error storing github issues - rolling back snapshot process")
+		return err
+	}
+
+	log.Println("Commiting transaction")
+	return tx.Commit()
+}
+
+// StoreGitHubIssues replaces today's copy of each of the given issues, leaving the rest of their repositories alone
+// This is used for single issues and pull requests, such as those linked from bugzilla
+func (c postgresClient) StoreGitHubIssues(issues []github.Issue) error {
+	tx, err := c.database.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	for _, issue := range issues {
+		_, err = tx.Exec(`DELETE FROM github_issues WHERE datestamp = ($1) AND repo = ($2) AND number = ($3)`, now, issue.Repo, issue.Number)
+		if err != nil {
+			return fmt.Errorf("unable to delete github issue %s#%d with date %v: %v", issue.Repo, issue.Number, now, err)
+		}
+	}
+
+	err = storeGitHubIssues(tx, issues)
+	if err != nil {
+		log.Println("Error storing github issues - rolling back snapshot process")
+		return err
+	}
+
+	return tx.Commit()
+}
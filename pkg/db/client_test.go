@@ -0,0 +1,23 @@
+package db
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/thrasher-redhat/internal-tools/pkg/db/migrations"
+)
+
+func TestHourlyColumns(t *testing.T) {
+	// Every hourly column has to exist in both tables, as compacting copies them back into bugs
+	for _, name := range []string{"0001_bugs.up.sql", "0009_bug_snapshots_hourly.up.sql"} {
+		body, err := migrations.Asset(name)
+		if err != nil {
+			t.Fatalf("unable to load migration %s: %v", name, err)
+		}
+		for _, column := range hourlyColumns {
+			if !regexp.MustCompile(`(?m)^\s+` + column + `\s`).Match(body) {
+				t.Errorf("expected migration %s to create column %q", name, column)
+			}
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/thrasher-redhat/internal-tools/pkg/bugzilla"
+)
+
+// The kinds of bug events
+const (
+	// EventAppeared is a bug that wasn't in the query's previous snapshot
+	EventAppeared = "appeared"
+	// EventDisappeared is a bug from the query's previous snapshot that is no longer found by the query
+	EventDisappeared = "disappeared"
+	// EventChanged is a change to one of the tracked fields of a bug
+	EventChanged = "changed"
+)
+
+// BugEvent is a difference in a bug between two consecutive snapshots of a query
+type BugEvent struct {
+	ID     int
+	Source string
+	Event  string
+	// Field, Old, and New are only set for changed events
+	Field string
+	Old   string
+	New   string
+}
+
+// trackedField is a field of a bug whose changes are recorded
+type trackedField struct {
+	name  string
+	value func(b bugzilla.Bug) string
+}
+
+// trackedFields are the fields whose changes are recorded, named after their columns
+// Lists are sorted so that a reordered list isn't a change
+var trackedFields = []trackedField{
+	{"status", func(b bugzilla.Bug) string { return b.Status }},
+	{"resolution", func(b bugzilla.Bug) string { return b.Resolution }},
+	{"assigned_to", func(b bugzilla.Bug) string { return b.AssignedTo }},
+	{"target_release", func(b bugzilla.Bug) string { return joinSorted(b.TargetRelease) }},
+	{"component", func(b bugzilla.Bug) string { return joinSorted(b.Component) }},
+	{"severity", func(b bugzilla.Bug) string { return b.Severity }},
+	{"priority", func(b bugzilla.Bug) string { return b.Priority }},
+	{"keywords", func(b bugzilla.Bug) string { return joinSorted(b.Keywords) }},
+	{"flags", func(b bugzilla.Bug) string { return joinSorted(b.Flags) }},
+}
+
+// trackedColumns are the columns of bugs that are read back into a bug for diffing
+const trackedColumns = "id, source, status, resolution, assigned_to, target_release, component, severity, priority, keywords, flags"
+
+// joinSorted joins a copy of the list in sorted order
+func joinSorted(list []string) string {
+	sorted := append([]string(nil), list...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, ", ")
+}
+
+// bugKey tells bugs apart, as bugs from different sources may share an id
+type bugKey struct {
+	source string
+	id     int
+}
+
+// diffBugs returns the events between the previous and current bugs of a query
+// Events are ordered by source and id, with appeared and disappeared before a bug's changed fields
+func diffBugs(previous, current []bugzilla.Bug) []BugEvent {
+	before := make(map[bugKey]bugzilla.Bug, len(previous))
+	for _, b := range previous {
+		before[bugKey{source(b), b.ID}] = b
+	}
+	after := make(map[bugKey]bugzilla.Bug, len(current))
+	for _, b := range current {
+		after[bugKey{source(b), b.ID}] = b
+	}
+
+	var events []BugEvent
+	for key, b := range after {
+		old, ok := before[key]
+		if !ok {
+			events = append(events, BugEvent{ID: key.id, Source: key.source, Event: EventAppeared})
+			continue
+		}
+		for _, f := range trackedFields {
+			o, n := f.value(old), f.value(b)
+			if o != n {
+				events = append(events, BugEvent{ID: key.id, Source: key.source, Event: EventChanged, Field: f.name, Old: o, New: n})
+			}
+		}
+	}
+	for key := range before {
+		if _, ok := after[key]; !ok {
+			events = append(events, BugEvent{ID: key.id, Source: key.source, Event: EventDisappeared})
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		a, b := events[i], events[j]
+		if a.Source != b.Source {
+			return a.Source < b.Source
+		}
+		if a.ID != b.ID {
+			return a.ID < b.ID
+		}
+		if (a.Event == EventChanged) != (b.Event == EventChanged) {
+			return b.Event == EventChanged
+		}
+		return a.Field < b.Field
+	})
+	return events
+}
+
+// previousBugs returns the tracked fields of the bugs in the query's latest snapshot before the given date
+// The date is zero if the query has no earlier snapshot
+func previousBugs(tx *sql.Tx, queryName string, t time.Time) ([]bugzilla.Bug, time.Time, error) {
+	var previous pq.NullTime
+	err := tx.QueryRow("SELECT MAX(datestamp) FROM bugs WHERE query_name = $1 AND datestamp < $2", queryName, t).Scan(&previous)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("unable to get the previous snapshot of query %q: %v", queryName, err)
+	}
+	if !previous.Valid {
+		return nil, time.Time{}, nil
+	}
+
+	rows, err := tx.Query("SELECT "+trackedColumns+" FROM bugs WHERE query_name = $1 AND datestamp = $2", queryName, previous.Time)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	defer rows.Close()
+
+	var bugs []bugzilla.Bug
+	for rows.Next() {
+		var b bugzilla.Bug
+		err = rows.Scan(&b.ID, &b.Source, &b.Status, &b.Resolution, &b.AssignedTo, &b.TargetRelease, &b.Component, &b.Severity, &b.Priority, &b.Keywords, &b.Flags)
+		if err != nil {
+			return nil, time.Time{}, fmt.Errorf("error scanning row for previous bugs of query %q: %v", queryName, err)
+		}
+		bugs = append(bugs, b)
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("error while scanning rows of previous bugs of query %q: %v", queryName, err)
+	}
+	return bugs, previous.Time, nil
+}
+
+// storeEvents replaces today's events for the query with the differences between the new bugs and the previous snapshot
+func storeEvents(tx *sql.Tx, queryName string, bugs bugzilla.Bugs) error {
+	t := time.Now()
+	_, err := tx.Exec(`DELETE FROM bug_events WHERE datestamp = $1 AND query_name = $2`, t, queryName)
+	if err != nil {
+		return fmt.Errorf("unable to delete events with date %v for query %q: %v", t.Format("2006-01-02"), queryName, err)
+	}
+
+	previous, previousDate, err := previousBugs(tx, queryName, t)
+	if err != nil {
+		return err
+	}
+	events := diffBugs(previous, bugs.Bugs)
+
+	stmt, err := tx.Prepare(pq.CopyIn("bug_events", "id", "source", "query_name", "datestamp", "previous_datestamp", "event", "field_name", "old_value", "new_value"))
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, e := range events {
+		_, err = stmt.Exec(e.ID, e.Source, queryName, t, nullTime(previousDate), e.Event, e.Field, e.Old, e.New)
+		if err != nil {
+			return fmt.Errorf("unable to insert %s event for bug %d: %v", e.Event, e.ID, err)
+		}
+	}
+
+	// Flushing buffered data
+	_, err = stmt.Exec()
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Stored %d events for query %q since %v\n", len(events), queryName, previousDate.Format("2006-01-02"))
+	return nil
+}
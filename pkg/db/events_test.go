@@ -0,0 +1,44 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/thrasher-redhat/internal-tools/pkg/bugzilla"
+)
+
+func TestDiffBugs(t *testing.T) {
+	previous := []bugzilla.Bug{
+		{ID: 1, Status: "NEW", Keywords: []string{"a", "b"}},
+		{ID: 2, Status: "NEW"},
+		{ID: 3, Status: "ASSIGNED", Source: "jira"},
+	}
+	current := []bugzilla.Bug{
+		{ID: 1, Status: "POST", Keywords: []string{"b", "a"}},
+		{ID: 3, Status: "ASSIGNED", Source: "jira"},
+		{ID: 3, Status: "NEW"},
+		{ID: 4, Status: "NEW", Component: []string{"web"}},
+	}
+
+	expected := []BugEvent{
+		{ID: 1, Source: bugzilla.Source, Event: EventChanged, Field: "status", Old: "NEW", New: "POST"},
+		{ID: 2, Source: bugzilla.Source, Event: EventDisappeared},
+		{ID: 3, Source: bugzilla.Source, Event: EventAppeared},
+		{ID: 4, Source: bugzilla.Source, Event: EventAppeared},
+	}
+	events := diffBugs(previous, current)
+	if len(events) != len(expected) {
+		t.Fatalf("expected %d events, got %d: %+v", len(expected), len(events), events)
+	}
+	for i := range expected {
+		if events[i] != expected[i] {
+			t.Errorf("expected event %d to be %+v, got %+v", i, expected[i], events[i])
+		}
+	}
+}
+
+func TestDiffBugsFirstSnapshot(t *testing.T) {
+	events := diffBugs(nil, []bugzilla.Bug{{ID: 1}, {ID: 2}})
+	if len(events) != 2 || events[0].Event != EventAppeared || events[1].Event != EventAppeared {
+		t.Errorf("expected every bug to appear, got %+v", events)
+	}
+}
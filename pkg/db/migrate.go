@@ -0,0 +1,276 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thrasher-redhat/internal-tools/pkg/db/migrations"
+)
+
+// MigrationClient knows how to check and change the version of the database schema
+type MigrationClient interface {
+	AppliedMigrations() (map[int]time.Time, error)
+	Migrate(int) error
+	CheckSchema() error
+}
+
+// Migration is a single versioned change to the database schema
+// Migrations are generated from database/migrations/<version>_<name>.(up|down).sql with go-bindata
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// String returns the file prefix of the migration, ex) 0001_bugs
+func (m Migration) String() string {
+	return fmt.Sprintf("%04d_%s", m.Version, m.Name)
+}
+
+// migrationFile matches the name of a migration file, ex) 0001_bugs.up.sql
+var migrationFile = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// migrationLock is the advisory lock held while migrating so that two migrations can't run at once
+const migrationLock = 7273482
+
+// BaseVersion is the last of the migrations that create the bugs table and its views
+// Reverting them drops every snapshot, including those from databases that were set up by hand before migrations
+const BaseVersion = 2
+
+// RevertsBaseSchema is whether migrating to the version would revert any applied base migrations
+func RevertsBaseSchema(version int, applied map[int]time.Time) bool {
+	for v := range applied {
+		if v > version && v <= BaseVersion {
+			return true
+		}
+	}
+	return false
+}
+
+// createMigrationsTable creates the table that records which migrations have been applied
+const createMigrationsTable = `CREATE TABLE IF NOT EXISTS schema_migrations (
+    version         integer NOT NULL PRIMARY KEY,
+    name            text NOT NULL,
+    applied_at      timestamptz NOT NULL DEFAULT now()
+)`
+
+// Migrations returns the migrations built into the binary, ordered by version
+func Migrations() ([]Migration, error) {
+	return parseMigrations(migrations.AssetNames(), migrations.Asset)
+}
+
+// LatestVersion returns the schema version that the binary expects
+func LatestVersion() (int, error) {
+	all, err := Migrations()
+	if err != nil {
+		return 0, err
+	}
+	if len(all) == 0 {
+		return 0, nil
+	}
+	return all[len(all)-1].Version, nil
+}
+
+// parseMigrations pairs up the named up and down files into migrations, ordered by version
+// Every migration needs both an up and a down file, and versions can't be reused
+func parseMigrations(names []string, asset func(string) ([]byte, error)) ([]Migration, error) {
+	byVersion := make(map[int]*Migration)
+	for _, name := range names {
+		match := migrationFile.FindStringSubmatch(name)
+		if match == nil {
+			return nil, fmt.Errorf("unexpected migration file %q, expected <version>_<name>.(up|down).sql", name)
+		}
+		version, err := strconv.Atoi(match[1])
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("invalid version in migration file %q", name)
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		}
+		if m.Name != match[2] {
+			return nil, fmt.Errorf("migrations %q and %q share version %d", m.Name, match[2], version)
+		}
+
+		body, err := asset(name)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read migration file %q: %v", name, err)
+		}
+		if match[3] == "up" {
+			m.Up = string(body)
+		} else {
+			m.Down = string(body)
+		}
+	}
+
+	all := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if strings.TrimSpace(m.Up) == "" || strings.TrimSpace(m.Down) == "" {
+			return nil, fmt.Errorf("migration %v needs both an up and a down file", m)
+		}
+		all = append(all, *m)
+	}
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].Version < all[j].Version
+	})
+	return all, nil
+}
+
+// AppliedMigrations returns the time each applied migration was applied, by version
+// A database that has never been migrated has no applied migrations
+func (c postgresClient) AppliedMigrations() (map[int]time.Time, error) {
+	applied := make(map[int]time.Time)
+
+	var table sql.NullString
+	err := c.database.QueryRow(`SELECT to_regclass('schema_migrations')::text`).Scan(&table)
+	if err != nil {
+		return nil, err
+	}
+	if !table.Valid {
+		return applied, nil
+	}
+
+	rows, err := c.database.Query(`SELECT version, applied_at FROM schema_migrations`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var version int
+		var appliedAt time.Time
+		err = rows.Scan(&version, &appliedAt)
+		if err != nil {
+			return nil, err
+		}
+		applied[version] = appliedAt
+	}
+	return applied, rows.Err()
+}
+
+// CheckSchema returns an error if any migration built into the binary has not been applied
+func (c postgresClient) CheckSchema() error {
+	all, err := Migrations()
+	if err != nil {
+		return err
+	}
+	applied, err := c.AppliedMigrations()
+	if err != nil {
+		return fmt.Errorf("unable to get the applied migrations: %v", err)
+	}
+
+	var pending []string
+	for _, m := range all {
+		if _, ok := applied[m.Version]; !ok {
+			pending = append(pending, m.String())
+		}
+	}
+	if len(pending) > 0 {
+		return fmt.Errorf("database schema is out of date, run the migrate command to apply: %s", strings.Join(pending, ", "))
+	}
+	return nil
+}
+
+// Migrate brings the database schema to the given version
+// Pending migrations up to the version are applied in order, then any applied migrations
+// past the version are reverted newest first.  Each migration runs in its own transaction.
+func (c postgresClient) Migrate(version int) error {
+	all, err := Migrations()
+	if err != nil {
+		return err
+	}
+
+	_, err = c.database.Exec(createMigrationsTable)
+	if err != nil {
+		return fmt.Errorf("unable to create the schema_migrations table: %v", err)
+	}
+	applied, err := c.AppliedMigrations()
+	if err != nil {
+		return fmt.Errorf("unable to get the applied migrations: %v", err)
+	}
+
+	// A newer binary may have applied migrations that we don't know how to revert
+	known := make(map[int]bool)
+	for _, m := range all {
+		known[m.Version] = true
+	}
+	for v := range applied {
+		if v > version && !known[v] {
+			return fmt.Errorf("migration %d is applied but is not built into this binary, unable to revert it", v)
+		}
+	}
+
+	for _, m := range all {
+		if _, ok := applied[m.Version]; ok || m.Version > version {
+			continue
+		}
+		err = c.runMigration(m, true)
+		if err != nil {
+			return err
+		}
+	}
+
+	for i := len(all) - 1; i >= 0; i-- {
+		m := all[i]
+		if _, ok := applied[m.Version]; !ok || m.Version <= version {
+			continue
+		}
+		err = c.runMigration(m, false)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runMigration applies (up) or reverts (down) a single migration and records it in schema_migrations
+func (c postgresClient) runMigration(m Migration, up bool) error {
+	tx, err := c.database.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`SELECT pg_advisory_xact_lock($1)`, migrationLock)
+	if err != nil {
+		return fmt.Errorf("unable to lock for migration %v: %v", m, err)
+	}
+
+	// Another migrate may have run this migration while we waited for the lock
+	var done bool
+	err = tx.QueryRow(`SELECT EXISTS (SELECT 1 FROM schema_migrations WHERE version = $1)`, m.Version).Scan(&done)
+	if err != nil {
+		return err
+	}
+	if done == up {
+		return nil
+	}
+
+	if up {
+		log.Printf("Applying migration %v", m)
+		_, err = tx.Exec(m.Up)
+		if err == nil {
+			_, err = tx.Exec(`INSERT INTO schema_migrations (version, name) VALUES ($1, $2)`, m.Version, m.Name)
+		}
+	} else {
+		log.Printf("Reverting migration %v", m)
+		_, err = tx.Exec(m.Down)
+		if err == nil {
+			_, err = tx.Exec(`DELETE FROM schema_migrations WHERE version = $1`, m.Version)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("migration %v failed: %v", m, err)
+	}
+
+	return tx.Commit()
+}
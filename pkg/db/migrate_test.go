@@ -0,0 +1,100 @@
+package db
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestParseMigrations(t *testing.T) {
+	files := map[string]string{
+		"0002_cards.up.sql":   "CREATE TABLE cards ();",
+		"0002_cards.down.sql": "DROP TABLE cards;",
+		"0010_later.up.sql":   "ALTER TABLE cards ADD COLUMN x integer;",
+		"0010_later.down.sql": "ALTER TABLE cards DROP COLUMN x;",
+		"0001_bugs.up.sql":    "CREATE TABLE bugs ();",
+		"0001_bugs.down.sql":  "DROP TABLE bugs;",
+	}
+	asset := func(name string) ([]byte, error) {
+		body, ok := files[name]
+		if !ok {
+			return nil, fmt.Errorf("not found")
+		}
+		return []byte(body), nil
+	}
+	var names []string
+	for name := range files {
+		names = append(names, name)
+	}
+
+	all, err := parseMigrations(names, asset)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []Migration{
+		{Version: 1, Name: "bugs", Up: "CREATE TABLE bugs ();", Down: "DROP TABLE bugs;"},
+		{Version: 2, Name: "cards", Up: "CREATE TABLE cards ();", Down: "DROP TABLE cards;"},
+		{Version: 10, Name: "later", Up: "ALTER TABLE cards ADD COLUMN x integer;", Down: "ALTER TABLE cards DROP COLUMN x;"},
+	}
+	if len(all) != len(expected) {
+		t.Fatalf("expected %d migrations, got %d", len(expected), len(all))
+	}
+	for i := range expected {
+		if all[i] != expected[i] {
+			t.Errorf("expected migration %d to be %+v, got %+v", i, expected[i], all[i])
+		}
+	}
+	if all[0].String() != "0001_bugs" {
+		t.Errorf("expected 0001_bugs, got %v", all[0])
+	}
+}
+
+func TestParseMigrationsErrors(t *testing.T) {
+	asset := func(name string) ([]byte, error) {
+		return []byte("SELECT 1;"), nil
+	}
+	tests := map[string][]string{
+		"bad name":       {"bugs.sql"},
+		"zero version":   {"0000_bugs.up.sql", "0000_bugs.down.sql"},
+		"missing down":   {"0001_bugs.up.sql"},
+		"missing up":     {"0001_bugs.down.sql"},
+		"shared version": {"0001_bugs.up.sql", "0001_bugs.down.sql", "0001_cards.up.sql", "0001_cards.down.sql"},
+	}
+
+	for name, names := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := parseMigrations(names, asset)
+			if err == nil {
+				t.Errorf("expected an error for %v", names)
+			}
+		})
+	}
+}
+
+func TestBuiltInMigrations(t *testing.T) {
+	all, err := Migrations()
+	if err != nil {
+		t.Fatalf("unable to load the built in migrations: %v", err)
+	}
+	if len(all) == 0 {
+		t.Fatal("expected built in migrations")
+	}
+	for i, m := range all {
+		if m.Version != i+1 {
+			t.Errorf("expected migration %v to have version %d", m, i+1)
+		}
+	}
+}
+
+func TestRevertsBaseSchema(t *testing.T) {
+	applied := map[int]time.Time{1: {}, 2: {}, 3: {}}
+	if RevertsBaseSchema(2, applied) || RevertsBaseSchema(3, applied) {
+		t.Error("expected reverting past the base migrations to be allowed")
+	}
+	if !RevertsBaseSchema(1, applied) || !RevertsBaseSchema(0, applied) {
+		t.Error("expected reverting the base migrations to be refused")
+	}
+	if RevertsBaseSchema(0, map[int]time.Time{}) {
+		t.Error("expected nothing to revert without applied migrations")
+	}
+}
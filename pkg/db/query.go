@@ -9,6 +9,8 @@ import (
 
 	"github.com/lib/pq"
 	"github.com/thrasher-redhat/internal-tools/pkg/bugzilla"
+	"github.com/thrasher-redhat/internal-tools/pkg/github"
+	"github.com/thrasher-redhat/internal-tools/pkg/trello"
 )
 
 // GetLatest provides the most recent datestamp in the database.
@@ -45,7 +47,7 @@ func (c postgresClient) GetEarliestDateForTargets(targets []string) (time.Time,
 		return time.Time{}, fmt.Errorf("unable to get earliest date for targets: invalid targets %q", targets)
 	}
 
-	err := c.database.QueryRow("SELECT MIN(datestamp) FROM bugs WHERE target_release = ANY($1)", pq.Array(targets)).Scan(&t)
+	err := c.database.QueryRow("SELECT MIN(datestamp) FROM bugs WHERE target_release && $1", pq.Array(targets)).Scan(&t)
 
 	if err != nil {
 		return time.Time{}, fmt.Errorf("error scanning row for earliest date with targets %q: %v", targets, err)
@@ -58,6 +60,7 @@ func (c postgresClient) GetEarliestDateForTargets(targets []string) (time.Time,
 // If the query is successful, but there are zero results, will return zerotime to be used as the previous date.
 func (c postgresClient) GetPreviousDate(date string) (time.Time, error) {
 	// TODO: Use a transaction...?
+	date = SnapshotDate(date)
 
 	// Check for the existence of given date.
 	var ct int
@@ -87,7 +90,7 @@ func (c postgresClient) GetPreviousDate(date string) (time.Time, error) {
 // Put together a base query/set of parameters and a conditional/second set of parameters
 // Conditional MUST only use a postgresql ARRAY[val1, val2, val3...] for parameters
 // and should have a SINGLE appropriate format specifier %v
-// Ex) "WHERE datestamp = %v" or "AND component = Any(ARRAY[%v])"
+// Ex) "WHERE datestamp = %v" or "AND component && ARRAY[%v]"
 // Note that a space is added between the query and conditional
 func appendQueryConditional(baseQuery string, baseArgs []interface{}, conditional string, conditionalArgs []interface{}) (string, []interface{}) {
 	var query string
@@ -107,30 +110,158 @@ func appendQueryConditional(baseQuery string, baseArgs []interface{}, conditiona
 	return query, args
 }
 
+// SnapshotDate returns the date (YYYY-MM-DD) of a snapshot given by either its date or a time (RFC3339)
+func SnapshotDate(date string) string {
+	t, err := time.Parse(time.RFC3339, date)
+	if err != nil {
+		return date
+	}
+	return t.Format("2006-01-02")
+}
+
+// bugsAt returns the table (named bugs) and the condition for the bugs in the snapshot at the given date, adding to args
+// A date (YYYY-MM-DD) uses the daily snapshot.  A time (RFC3339) uses the last hourly snapshot of each query
+// taken at or before that time on the same day.
+func bugsAt(date string, args []interface{}) (string, string, []interface{}) {
+	n := len(args) + 1
+	t, err := time.Parse(time.RFC3339, date)
+	if err != nil {
+		return "bugs", fmt.Sprintf("bugs.datestamp = $%d", n), append(args, date)
+	}
+	where := fmt.Sprintf("(bugs.query_name, bugs.snapshot_time) IN (SELECT query_name, MAX(snapshot_time) FROM bug_snapshots_hourly WHERE datestamp = $%d AND snapshot_time <= $%d GROUP BY query_name)", n, n+1)
+	return "bug_snapshots_hourly AS bugs", where, append(args, SnapshotDate(date), t)
+}
+
+// BugFilter narrows down the bugs used by a query
+// Empty fields are ignored
+type BugFilter struct {
+	// QueryName only includes bugs from the named snapshot query
+	QueryName  string
+	Components []string
+	Keywords   []string
+	// CustomerCaseTrackers matches bugs with an external bug in any of the customer portal trackers
+	CustomerCaseTrackers []int
+	TargetReleases       []string
+	Severities           []string
+	Priorities           []string
+	// Flags matches bugs with any of the given flags, ex) blocker+
+	Flags []string
+	// CustomFields matches bugs using fields from the raw bugzilla json
+	CustomFields []FieldFilter
+	// UntouchedSince matches bugs with no changes or comments since the given time
+	UntouchedSince time.Time
+	// ExternalTrackers matches bugs with an external bug in any of the given trackers
+	ExternalTrackers []int
+	// ExternalBugs matches bugs linked to any of the given external ids, ex) a case number
+	ExternalBugs []string
+	// IDs matches bugs with any of the given ids
+	IDs []int
+	// Sources matches bugs from any of the given trackers, ex) bugzilla or jira
+	Sources []string
+}
+
+// intsToInterfaces converts an int slice to an interface slice for use as query arguments
+func intsToInterfaces(ints []int) []interface{} {
+	typeless := make([]interface{}, len(ints))
+	for i := range ints {
+		typeless[i] = ints[i]
+	}
+	return typeless
+}
+
+// externalTrackerConditional matches bugs with an external bug in any of the trackers given as an ARRAY
+const externalTrackerConditional = "AND EXISTS (SELECT 1 FROM jsonb_array_elements(bugs.externals) AS ext WHERE CAST(ext->>'ext_bz_id' AS INT) = Any(ARRAY[%v]::int[]))"
+
+// FieldFilter matches bugs where the named field has any of the given values
+// A field holding a list matches if any element is one of the values
+type FieldFilter struct {
+	Name   string
+	Values []string
+}
+
+// toInterfaces converts a string slice to an interface slice for use as query arguments
+func toInterfaces(strs []string) []interface{} {
+	typeless := make([]interface{}, len(strs))
+	for i := range strs {
+		typeless[i] = strs[i]
+	}
+	return typeless
+}
+
+// appendFilter adds the conditionals for the filter to the query
+// Columns are qualified with "bugs." so that the query may join other tables
+func appendFilter(query string, args []interface{}, filter BugFilter) (string, []interface{}) {
+	if filter.QueryName != "" {
+		query, args = appendQueryConditional(query, args, "AND bugs.query_name = %v", []interface{}{filter.QueryName})
+	}
+	if len(filter.Components) > 0 {
+		// Components are a list, so match bugs with any of the given components
+		query, args = appendQueryConditional(query, args, "AND bugs.component && ARRAY[%v]", toInterfaces(filter.Components))
+	}
+	if len(filter.Keywords) > 0 {
+		query, args = appendQueryConditional(query, args, "AND bugs.keywords && ARRAY[%v]", toInterfaces(filter.Keywords))
+	}
+	if len(filter.CustomerCaseTrackers) > 0 {
+		// Query the jsonb directly
+		// We care about external bz sources with the ids that match the "Red Hat Customer Portal"
+		query, args = appendQueryConditional(query, args, externalTrackerConditional, intsToInterfaces(filter.CustomerCaseTrackers))
+	}
+	if len(filter.TargetReleases) > 0 {
+		query, args = appendQueryConditional(query, args, "AND bugs.target_release && ARRAY[%v]", toInterfaces(filter.TargetReleases))
+	}
+	if len(filter.Severities) > 0 {
+		query, args = appendQueryConditional(query, args, "AND bugs.severity = Any(ARRAY[%v])", toInterfaces(filter.Severities))
+	}
+	if len(filter.Priorities) > 0 {
+		query, args = appendQueryConditional(query, args, "AND bugs.priority = Any(ARRAY[%v])", toInterfaces(filter.Priorities))
+	}
+	if len(filter.Flags) > 0 {
+		query, args = appendQueryConditional(query, args, "AND bugs.flags && ARRAY[%v]", toInterfaces(filter.Flags))
+	}
+	for _, f := range filter.CustomFields {
+		// ?| matches a json string or an element of a json array, ->> covers numbers and booleans
+		name := len(args) + 1
+		args = append(args, f.Name)
+		query, args = appendQueryConditional(query, args, fmt.Sprintf("AND (bugs.raw->$%d::text ?| ARRAY[%%[1]v]::text[] OR bugs.raw->>$%d::text = Any(ARRAY[%%[1]v]::text[]))", name, name), toInterfaces(f.Values))
+	}
+	if len(filter.ExternalTrackers) > 0 {
+		query, args = appendQueryConditional(query, args, externalTrackerConditional, intsToInterfaces(filter.ExternalTrackers))
+	}
+	if len(filter.ExternalBugs) > 0 {
+		query, args = appendQueryConditional(query, args, "AND EXISTS (SELECT 1 FROM jsonb_array_elements(bugs.externals) AS ext WHERE ext->>'ext_bz_bug_id' = Any(ARRAY[%v]::text[]))", toInterfaces(filter.ExternalBugs))
+	}
+	if len(filter.IDs) > 0 {
+		query, args = appendQueryConditional(query, args, "AND bugs.id = Any(ARRAY[%v]::int[])", intsToInterfaces(filter.IDs))
+	}
+	if len(filter.Sources) > 0 {
+		query, args = appendQueryConditional(query, args, "AND bugs.source = Any(ARRAY[%v])", toInterfaces(filter.Sources))
+	}
+	if !filter.UntouchedSince.IsZero() {
+		// GREATEST ignores NULLs, so bugs without a last change time only use their comments
+		query, args = appendQueryConditional(query, args, "AND GREATEST(bugs.last_change_time, bugs.last_comment_time) < %v", []interface{}{filter.UntouchedSince})
+	}
+	return query, args
+}
+
 // Functions to query the database
 
 // getBugs queries for a list of bugs
-func (c postgresClient) GetBugs(datestamp string, components []string) ([]bugzilla.Bug, error) {
+// A bug that is in more than one snapshot query is only returned once
+// Bugs from different sources may share an id, so bugs are told apart by source and id
+func (c postgresClient) GetBugs(datestamp string, filter BugFilter) ([]bugzilla.Bug, error) {
 	// Base query
 	// Grabs all components of the bug from bugs
 	// Also grabs the "bug age", the difference between the given datestamp
 	// and the MIN(datestamp) for that id (using a view)
-	query := "SELECT bugs.id, bugs.component, bugs.target_release, bugs.assigned_to, bugs.status, bugs.summary, bugs.keywords, bugs.cf_pm_score, bugs.externals, bugs.datestamp, bugs.datestamp - bug_age.min FROM bugs, bug_age WHERE bugs.datestamp = $1 AND bugs.id = bug_age.id"
-	args := []interface{}{datestamp}
-
-	// Check if we need to filter by component and update query if so
-	if components != nil {
-		// Convert components to an interface slice
-		typelessComponents := make([]interface{}, len(components))
-		for i := range components {
-			typelessComponents[i] = components[i]
-		}
-		// Append the query and arguments together
-		query, args = appendQueryConditional(query, args, "AND bugs.component = Any(ARRAY[%v])", typelessComponents)
-	}
+	// A bug only seen in an hourly snapshot isn't in the view yet, so it has an age of zero
+	from, where, args := bugsAt(datestamp, nil)
+	query := "SELECT DISTINCT ON (bugs.source, bugs.id) bugs.id, bugs.component, bugs.target_release, bugs.assigned_to, bugs.status, bugs.summary, bugs.keywords, bugs.cf_pm_score, bugs.externals, bugs.datestamp, COALESCE(bugs.datestamp - bug_age.min, 0) AS age, bugs.query_name, bugs.severity, bugs.priority, bugs.reporter, bugs.creation_time, bugs.last_change_time, bugs.resolution, bugs.whiteboard, bugs.flags, bugs.raw, bugs.comment_count, bugs.last_comment_time, bugs.last_commenter, bugs.depends_on, bugs.blocks, bugs.dupe_of, bugs.clone_of, bugs.source FROM " + from + " LEFT JOIN bug_age ON bugs.id = bug_age.id AND bugs.source = bug_age.source WHERE " + where
 
-	// Sort by pmScore
-	query += " ORDER BY bugs.cf_pm_score DESC"
+	// Filter by component, query, etc. if needed
+	query, args = appendFilter(query, args, filter)
+
+	// DISTINCT ON needs to be sorted by source and id, so sort by pmScore outside of it
+	query = "SELECT * FROM (" + query + " ORDER BY bugs.source, bugs.id, bugs.query_name) AS distinct_bugs ORDER BY cf_pm_score DESC"
 
 	rows, err := c.database.Query(query, args...)
 	if err != nil {
@@ -143,6 +274,7 @@ func (c postgresClient) GetBugs(datestamp string, components []string) ([]bugzil
 
 	for rows.Next() {
 		var b bugzilla.Bug
+		var creationTime, lastChangeTime, lastCommentTime pq.NullTime
 		err = rows.Scan(
 			&b.ID,
 			&b.Component,
@@ -155,10 +287,31 @@ func (c postgresClient) GetBugs(datestamp string, components []string) ([]bugzil
 			&b.Externals,
 			&b.DateStamp,
 			&b.Age,
+			&b.QueryName,
+			&b.Severity,
+			&b.Priority,
+			&b.Reporter,
+			&creationTime,
+			&lastChangeTime,
+			&b.Resolution,
+			&b.Whiteboard,
+			&b.Flags,
+			&b.Raw,
+			&b.Comments.Count,
+			&lastCommentTime,
+			&b.Comments.LastCommenter,
+			&b.DependsOn,
+			&b.Blocks,
+			&b.DupeOf,
+			&b.CloneOf,
+			&b.Source,
 		)
 		if err != nil {
 			log.Printf("Error scanning row: %v", err)
 		} else {
+			b.CreationTime = creationTime.Time
+			b.LastChangeTime = lastChangeTime.Time
+			b.Comments.LastCommentTime = lastCommentTime.Time
 			bugs = append(bugs, b)
 		}
 	}
@@ -178,72 +331,65 @@ type Breakdown struct {
 }
 
 // GetBreakdown calculates the counts for total bugs, new bugs, and closed bugs
-func (c postgresClient) GetBreakdown(startDate, endDate string, components []string, keywords []string, custCase bool, targetReleases []string) (Breakdown, error) {
+// Bugs are counted by source and id, so a bug in more than one snapshot query is only counted once
+func (c postgresClient) GetBreakdown(startDate, endDate string, filter BugFilter) (Breakdown, error) {
 	var total int
 	var new int
 	var closed int
 
-	// Setup the base query
-	query := "SELECT COUNT(id) FROM bugs WHERE datestamp = $1"
-	args := []interface{}{endDate}
-
-	// QUERY CRAFTING
-	if components != nil {
-		// Convert components to an interface slice
-		typelessComponents := make([]interface{}, len(components))
-		for i := range components {
-			typelessComponents[i] = components[i]
+	// count builds the query counting the bugs on a date, leaving out the bugs that were also there on the other date
+	// Either date may be the time of an hourly snapshot
+	count := func(date, other string) (string, []interface{}) {
+		from, where, args := bugsAt(date, nil)
+		query, args := appendFilter("SELECT COUNT(DISTINCT (bugs.source, bugs.id)) FROM "+from+" WHERE "+where, args, filter)
+		if other == "" {
+			return query, args
 		}
-		// Append the query and arguments together
-		query, args = appendQueryConditional(query, args, "AND component = Any(ARRAY[%v])", typelessComponents)
-	}
-	if len(keywords) > 0 {
-		// Convert keywords to an interface slice
-		typelessKeywords := make([]interface{}, len(keywords))
-		for i := range keywords {
-			typelessKeywords[i] = keywords[i]
-		}
-		// Append the query and arguments together
-		query, args = appendQueryConditional(query, args, "AND keywords && ARRAY[%v]", typelessKeywords)
-	}
-	if custCase == true {
-		// Query the jsonb directly
-		// We care about external bz sources with the id that matches the "Red Hat Customer Portal"
-		query, args = appendQueryConditional(query, args, "AND %v in (SELECT CAST( jsonb_array_elements(bugs.externals)->>'ext_bz_id' AS INT))", []interface{}{bugzilla.ExternalID})
-	}
-	if targetReleases != nil && len(targetReleases) > 0 {
-		// Convert keywords to an interface slice
-		typelessTargets := make([]interface{}, len(targetReleases))
-		for i := range targetReleases {
-			typelessTargets[i] = targetReleases[i]
+
+		// When filtering by snapshot query, a bug only counts as seen on the other date if it was in the same query
+		from, where, args = bugsAt(other, args)
+		subQuery := "SELECT bugs.source, bugs.id FROM " + from + " WHERE " + where
+		if filter.QueryName != "" {
+			subQuery, args = appendQueryConditional(subQuery, args, "AND bugs.query_name = %v", []interface{}{filter.QueryName})
 		}
-		query, args = appendQueryConditional(query, args, "AND target_release = Any(ARRAY[%v])", typelessTargets)
+		return query + " AND (bugs.source, bugs.id) NOT IN (" + subQuery + ")", args
 	}
 
 	// Get the TOTAL number of bugs on the given day
+	query, args := count(endDate, "")
 	err := c.database.QueryRow(query, args...).Scan(&total)
 	if err != nil {
 		log.Printf("Error querying for TOTAL bug count: %v", err)
 		return Breakdown{}, err
 	}
 
-	// Get the number of bugs that are NEW on the given day
-	// We use the query for TOTAL and check against all bugs that existed on the previous date
-	subQuery := "AND id NOT IN (SELECT id FROM bugs WHERE datestamp = %v)"
-	newQuery, newArgs := appendQueryConditional(query, args, subQuery, []interface{}{startDate})
+	// The new and closed bugs are read from the events when every query's snapshot on the end date follows one on the
+	// start date.  Otherwise (hourly snapshots, skipped days, or dates further apart) the snapshots are compared directly.
+	useEvents, err := c.eventsCover(startDate, endDate, filter.QueryName)
+	if err != nil {
+		log.Printf("Error checking the events between %q and %q: %v", startDate, endDate, err)
+		return Breakdown{}, err
+	}
 
-	err = c.database.QueryRow(newQuery, newArgs...).Scan(&new)
+	// Get the number of bugs that are NEW on the given day
+	if useEvents {
+		query, args = countEvents(EventAppeared, startDate, endDate, filter)
+	} else {
+		query, args = count(endDate, startDate)
+	}
+	err = c.database.QueryRow(query, args...).Scan(&new)
 	if err != nil {
 		log.Printf("Error querying for NEW bug count: %v", err)
 		return Breakdown{}, err
 	}
 
 	// See which of the bugs from "yesterday" are not in "today's" bug list
-	// Uses the same query EXCEPT that the first element in closedArgs is startDate instead of endDate
-	closedQuery, closedArgs := appendQueryConditional(query, args, "AND id NOT IN (SELECT id FROM bugs where datestamp = %v)", []interface{}{endDate})
-	closedArgs[0] = startDate
-
-	err = c.database.QueryRow(closedQuery, closedArgs...).Scan(&closed)
+	if useEvents {
+		query, args = countEvents(EventDisappeared, startDate, endDate, filter)
+	} else {
+		query, args = count(startDate, endDate)
+	}
+	err = c.database.QueryRow(query, args...).Scan(&closed)
 	if err != nil {
 		log.Printf("Error querying for CLOSED bug count: %v", err)
 		return Breakdown{}, err
@@ -255,3 +401,468 @@ func (c postgresClient) GetBreakdown(startDate, endDate string, components []str
 		Closed: closed,
 	}, nil
 }
+
+// querySnapshot is whether a snapshot query has a snapshot on a date, and the date of its snapshot before that
+type querySnapshot struct {
+	name     string
+	present  bool
+	previous time.Time
+}
+
+// eventsCover is whether the events of every query on the end date are relative to the start date
+// Each query in either snapshot has to have been snapshotted on both dates, with nothing in between
+func (c postgresClient) eventsCover(startDate, endDate, queryName string) (bool, error) {
+	_, startErr := time.Parse(time.RFC3339, startDate)
+	_, endErr := time.Parse(time.RFC3339, endDate)
+	if startErr == nil || endErr == nil {
+		return false, nil
+	}
+
+	query, args := querySnapshots(startDate, endDate, queryName)
+	rows, err := c.database.Query(query, args...)
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+
+	var snapshots []querySnapshot
+	for rows.Next() {
+		var s querySnapshot
+		var previous pq.NullTime
+		err = rows.Scan(&s.name, &s.present, &previous)
+		if err != nil {
+			return false, fmt.Errorf("error scanning row for query snapshots: %v", err)
+		}
+		s.previous = previous.Time
+		snapshots = append(snapshots, s)
+	}
+	err = rows.Err()
+	if err != nil {
+		return false, fmt.Errorf("error while scanning rows of query snapshots: %v", err)
+	}
+
+	return consecutiveSnapshots(startDate, snapshots), nil
+}
+
+// querySnapshots builds the query for the snapshots of each query found on either date, or only the named query
+func querySnapshots(startDate, endDate, queryName string) (string, []interface{}) {
+	query := `SELECT q.query_name,
+		EXISTS (SELECT 1 FROM bugs WHERE bugs.query_name = q.query_name AND bugs.datestamp = $2),
+		(SELECT MAX(bugs.datestamp) FROM bugs WHERE bugs.query_name = q.query_name AND bugs.datestamp < $2)
+		FROM (SELECT DISTINCT query_name FROM bugs WHERE datestamp IN ($1, $2)) AS q`
+	args := []interface{}{startDate, endDate}
+	if queryName != "" {
+		query, args = appendQueryConditional(query, args, "WHERE q.query_name = %v", []interface{}{queryName})
+	}
+	return query, args
+}
+
+// consecutiveSnapshots is whether each query's snapshot on the end date directly follows its snapshot on the start date
+// A query without a snapshot on the end date, or whose first snapshot is on the end date, has no events to compare with
+func consecutiveSnapshots(startDate string, snapshots []querySnapshot) bool {
+	for _, s := range snapshots {
+		if !s.present || s.previous.IsZero() || s.previous.Format("2006-01-02") != startDate {
+			return false
+		}
+	}
+	return true
+}
+
+// countEvents builds the query counting the bugs that appeared in or disappeared from their queries between the
+// snapshots on the start and end dates.  Only events relative to the start date are counted, see eventsCover.
+// The filter is matched against the bug as it was in the snapshot where it was last seen
+// Without a query name filter, bugs that only moved between queries aren't counted
+func countEvents(event, startDate, endDate string, filter BugFilter) (string, []interface{}) {
+	// An appeared bug is found in the end date's snapshot, while a disappeared one is in the query's previous snapshot
+	seenOn, otherDate := "bug_events.datestamp", startDate
+	if event == EventDisappeared {
+		seenOn, otherDate = "bug_events.previous_datestamp", endDate
+	}
+
+	query := `SELECT COUNT(DISTINCT (bugs.source, bugs.id)) FROM bug_events
+		JOIN bugs ON bugs.id = bug_events.id AND bugs.source = bug_events.source AND bugs.query_name = bug_events.query_name AND bugs.datestamp = ` + seenOn + `
+		WHERE bug_events.event = $1 AND bug_events.datestamp = $2 AND bug_events.previous_datestamp = $3`
+	query, args := appendFilter(query, []interface{}{event, endDate, startDate}, filter)
+	if filter.QueryName != "" {
+		return query, args
+	}
+	args = append(args, otherDate)
+	return query + fmt.Sprintf(" AND NOT EXISTS (SELECT 1 FROM bugs other WHERE other.datestamp = $%d AND other.source = bugs.source AND other.id = bugs.id)", len(args)), args
+}
+
+// GetHistoryFetchTimes finds when the stored history of each of the given bugs was last fetched
+// Bugs without any stored changes are left out of the map
+func (c postgresClient) GetHistoryFetchTimes(ids []int) (map[int]time.Time, error) {
+	rows, err := c.database.Query("SELECT bug_id, MAX(fetched_at) FROM bug_changes WHERE bug_id = ANY($1) AND fetched_at IS NOT NULL GROUP BY bug_id", pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	times := make(map[int]time.Time)
+	for rows.Next() {
+		var id int
+		var t time.Time
+		err = rows.Scan(&id, &t)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning row for history fetch time: %v", err)
+		}
+		times[id] = t
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("error while scanning rows of history fetch times: %v", err)
+	}
+
+	return times, nil
+}
+
+// BugChange is a single change to a field of a bug
+type BugChange struct {
+	When    time.Time
+	Who     string
+	Field   string
+	Removed string
+	Added   string
+}
+
+// GetHistory provides the stored changes to the given bug, oldest first
+func (c postgresClient) GetHistory(id int) ([]BugChange, error) {
+	rows, err := c.database.Query("SELECT change_time, who, field_name, removed, added FROM bug_changes WHERE bug_id = $1 ORDER BY change_time", id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var changes []BugChange
+	for rows.Next() {
+		var ch BugChange
+		err = rows.Scan(&ch.When, &ch.Who, &ch.Field, &ch.Removed, &ch.Added)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning row for changes to bug %d: %v", id, err)
+		}
+		changes = append(changes, ch)
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("error while scanning rows of changes to bug %d: %v", id, err)
+	}
+
+	return changes, nil
+}
+
+// cardColumns are the columns scanned by scanCards, including the linked bug ids
+const cardColumns = "cards.id, cards.name, cards.list, cards.url, cards.datestamp, cards.labels, cards.members, cards.due, cards.last_activity, cards.closed, " +
+	"ARRAY(SELECT card_bugs.bug_id FROM card_bugs WHERE card_bugs.card_id = cards.id AND card_bugs.board = cards.board AND card_bugs.datestamp = cards.datestamp ORDER BY card_bugs.bug_id)"
+
+// scanCards reads rows of cardColumns into cards
+func scanCards(rows *sql.Rows) ([]trello.Card, error) {
+	var cards []trello.Card
+	for rows.Next() {
+		var card trello.Card
+		var due, lastActivity pq.NullTime
+		var bugs pq.Int64Array
+		err := rows.Scan(
+			&card.ID,
+			&card.Name,
+			&card.List,
+			&card.URL,
+			&card.DateStamp,
+			pq.Array(&card.Labels),
+			pq.Array(&card.Members),
+			&due,
+			&lastActivity,
+			&card.Closed,
+			&bugs,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning row for cards: %v", err)
+		}
+		card.Due = due.Time
+		card.LastActivity = lastActivity.Time
+		for _, id := range bugs {
+			card.Bugs = append(card.Bugs, int(id))
+		}
+		cards = append(cards, card)
+	}
+	err := rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("error while scanning rows of cards: %v", err)
+	}
+
+	return cards, nil
+}
+
+// GetCards provides the cards on the named board for the given datestamp
+// An empty datestamp uses the latest snapshot of the board
+func (c postgresClient) GetCards(board, datestamp string) ([]trello.Card, error) {
+	query := "SELECT " + cardColumns + " FROM cards WHERE cards.board = $1 AND cards.datestamp = $2 ORDER BY cards.list, cards.name"
+	args := []interface{}{board, datestamp}
+	if datestamp == "" {
+		query = "SELECT " + cardColumns + " FROM cards WHERE cards.board = $1 AND cards.datestamp = (SELECT MAX(datestamp) FROM cards WHERE board = $1) ORDER BY cards.list, cards.name"
+		args = []interface{}{board}
+	}
+
+	rows, err := c.database.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	cards, err := scanCards(rows)
+	if err != nil {
+		return nil, fmt.Errorf("board %q: %v", board, err)
+	}
+	return cards, nil
+}
+
+// GetCardsForBug provides the cards on any board that reference the given bug on the given datestamp
+func (c postgresClient) GetCardsForBug(id int, datestamp string) ([]trello.Card, error) {
+	query := "SELECT " + cardColumns + " FROM cards, card_bugs WHERE card_bugs.bug_id = $1 AND card_bugs.datestamp = $2 AND cards.id = card_bugs.card_id AND cards.board = card_bugs.board AND cards.datestamp = card_bugs.datestamp ORDER BY cards.board, cards.list, cards.name"
+
+	rows, err := c.database.Query(query, id, datestamp)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	cards, err := scanCards(rows)
+	if err != nil {
+		return nil, fmt.Errorf("bug %d: %v", id, err)
+	}
+	return cards, nil
+}
+
+// GetCardDates provides the dates that the named board was snapshotted, oldest first
+func (c postgresClient) GetCardDates(board string) ([]time.Time, error) {
+	rows, err := c.database.Query("SELECT DISTINCT datestamp FROM cards WHERE board = $1 ORDER BY datestamp", board)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var dates []time.Time
+	for rows.Next() {
+		var t time.Time
+		err = rows.Scan(&t)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning row for card dates: %v", err)
+		}
+		dates = append(dates, t)
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("error while scanning rows of card dates: %v", err)
+	}
+
+	return dates, nil
+}
+
+// ListFlow represents the cards in a trello list on one date compared to an earlier date
+type ListFlow struct {
+	List string
+	// Total is the number of cards in the list
+	Total int
+	// Entered is the number of cards in the list that were in another list, or not on the board, on the earlier date
+	Entered int
+	// Left is the number of cards in the list on the earlier date that have moved to another list or off the board
+	Left int
+}
+
+// GetListFlow calculates the flow of cards through each list of the named board between two dates
+// Cards are matched by id, so a card that moved between lists has left one list and entered another
+// Lists that only had cards on the start date are included with a total of zero
+func (c postgresClient) GetListFlow(board, startDate, endDate string) ([]ListFlow, error) {
+	query := `WITH today AS (SELECT id, list FROM cards WHERE board = $1 AND datestamp = $3),
+		earlier AS (SELECT id, list FROM cards WHERE board = $1 AND datestamp = $2)
+	SELECT list, SUM(total), SUM(entered), SUM(exited) FROM (
+		SELECT today.list, 1 AS total, CASE WHEN earlier.list IS DISTINCT FROM today.list THEN 1 ELSE 0 END AS entered, 0 AS exited
+		FROM today LEFT JOIN earlier ON earlier.id = today.id
+		UNION ALL
+		SELECT earlier.list, 0, 0, 1
+		FROM earlier LEFT JOIN today ON today.id = earlier.id
+		WHERE today.list IS DISTINCT FROM earlier.list
+	) flow GROUP BY list ORDER BY list`
+
+	rows, err := c.database.Query(query, board, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var flows []ListFlow
+	for rows.Next() {
+		var flow ListFlow
+		err = rows.Scan(&flow.List, &flow.Total, &flow.Entered, &flow.Left)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning row for list flow: %v", err)
+		}
+		flows = append(flows, flow)
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("error while scanning rows of list flow: %v", err)
+	}
+
+	return flows, nil
+}
+
+// githubColumns are the columns scanned by scanGitHubIssues
+const githubColumns = "repo, number, datestamp, title, state, url, pull_request, merged_at, labels, milestone, assignees, author, created_at, updated_at, closed_at"
+
+// scanGitHubIssues reads rows of githubColumns into issues
+func scanGitHubIssues(rows *sql.Rows) ([]github.Issue, error) {
+	var issues []github.Issue
+	for rows.Next() {
+		var issue github.Issue
+		var mergedAt, createdAt, updatedAt, closedAt pq.NullTime
+		err := rows.Scan(
+			&issue.Repo,
+			&issue.Number,
+			&issue.DateStamp,
+			&issue.Title,
+			&issue.State,
+			&issue.URL,
+			&issue.PullRequest,
+			&mergedAt,
+			pq.Array(&issue.Labels),
+			&issue.Milestone,
+			pq.Array(&issue.Assignees),
+			&issue.Author,
+			&createdAt,
+			&updatedAt,
+			&closedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning row for github issues: %v", err)
+		}
+		issue.MergedAt = mergedAt.Time
+		issue.CreatedAt = createdAt.Time
+		issue.UpdatedAt = updatedAt.Time
+		issue.ClosedAt = closedAt.Time
+		issues = append(issues, issue)
+	}
+	err := rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("error while scanning rows of github issues: %v", err)
+	}
+
+	return issues, nil
+}
+
+// GetGitHubIssues provides the issues and pull requests stored for the repository on the given datestamp
+// An empty datestamp uses the latest snapshot of the repository
+func (c postgresClient) GetGitHubIssues(repo, datestamp string) ([]github.Issue, error) {
+	query := "SELECT " + githubColumns + " FROM github_issues WHERE repo = $1 AND datestamp = $2 ORDER BY number"
+	args := []interface{}{repo, datestamp}
+	if datestamp == "" {
+		query = "SELECT " + githubColumns + " FROM github_issues WHERE repo = $1 AND datestamp = (SELECT MAX(datestamp) FROM github_issues WHERE repo = $1) ORDER BY number"
+		args = []interface{}{repo}
+	}
+
+	rows, err := c.database.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	issues, err := scanGitHubIssues(rows)
+	if err != nil {
+		return nil, fmt.Errorf("repo %q: %v", repo, err)
+	}
+	return issues, nil
+}
+
+// GetGitHubIssue provides the latest stored copy of a single issue or pull request on or before the given datestamp
+// Returns nil if the issue was never stored
+func (c postgresClient) GetGitHubIssue(repo string, number int, datestamp string) (*github.Issue, error) {
+	rows, err := c.database.Query("SELECT "+githubColumns+" FROM github_issues WHERE repo = $1 AND number = $2 AND datestamp <= $3 ORDER BY datestamp DESC LIMIT 1", repo, number, datestamp)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	issues, err := scanGitHubIssues(rows)
+	if err != nil {
+		return nil, fmt.Errorf("github issue %s#%d: %v", repo, number, err)
+	}
+	if len(issues) == 0 {
+		return nil, nil
+	}
+	return &issues[0], nil
+}
+
+// SnapshotRun is a record of a single run of a snapshot query
+type SnapshotRun struct {
+	ID         int
+	QueryName  string
+	Source     string
+	StartedAt  time.Time
+	FinishedAt time.Time
+	// FetchDuration is how long the tracker took to return the bugs
+	FetchDuration time.Duration
+	// BugCount is the number of bugs stored
+	BugCount int
+	// Removed is the number of bugs from an earlier run that day that were replaced
+	Removed int
+	// Error is why the run failed, or empty if it succeeded
+	Error string
+	// Quarantined is whether the bugs were set aside instead of stored because their counts looked wrong
+	Quarantined bool
+	// Hourly also keeps the bugs in the hourly snapshots under the time the run started
+	Hourly bool
+}
+
+// GetSnapshotRuns provides the most recent snapshot runs, newest first
+func (c postgresClient) GetSnapshotRuns(limit int) ([]SnapshotRun, error) {
+	rows, err := c.database.Query("SELECT id, query_name, source, started_at, finished_at, fetch_seconds, bug_count, removed_count, error, quarantined FROM snapshot_runs ORDER BY started_at DESC, id DESC LIMIT $1", limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var runs []SnapshotRun
+	for rows.Next() {
+		var run SnapshotRun
+		var seconds float64
+		err = rows.Scan(&run.ID, &run.QueryName, &run.Source, &run.StartedAt, &run.FinishedAt, &seconds, &run.BugCount, &run.Removed, &run.Error, &run.Quarantined)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning row for snapshot runs: %v", err)
+		}
+		run.FetchDuration = time.Duration(seconds * float64(time.Second))
+		runs = append(runs, run)
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("error while scanning rows of snapshot runs: %v", err)
+	}
+
+	return runs, nil
+}
+
+// GetPreviousComponentCounts provides the number of bugs in each primary component from the query's latest snapshot before today
+// Returns an empty map if the query has no earlier snapshot
+func (c postgresClient) GetPreviousComponentCounts(queryName string) (map[string]int, error) {
+	rows, err := c.database.Query("SELECT COALESCE(component[1], ''), COUNT(*) FROM bugs WHERE query_name = $1 AND datestamp = (SELECT MAX(datestamp) FROM bugs WHERE query_name = $1 AND datestamp < $2) GROUP BY 1", queryName, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var component string
+		var count int
+		err = rows.Scan(&component, &count)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning row for counts of query %q: %v", queryName, err)
+		}
+		counts[component] = count
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("error while scanning rows of counts of query %q: %v", queryName, err)
+	}
+
+	return counts, nil
+}
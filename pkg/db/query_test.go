@@ -0,0 +1,120 @@
+package db
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBugsAt(t *testing.T) {
+	from, where, args := bugsAt("2018-06-01", []interface{}{"x"})
+	if from != "bugs" || where != "bugs.datestamp = $2" || len(args) != 2 || args[1] != "2018-06-01" {
+		t.Errorf("unexpected daily snapshot: %q %q %v", from, where, args)
+	}
+
+	from, where, args = bugsAt("2018-06-01T15:00:00-04:00", nil)
+	if from != "bug_snapshots_hourly AS bugs" {
+		t.Errorf("expected the hourly snapshots, got %q", from)
+	}
+	expected := "(bugs.query_name, bugs.snapshot_time) IN (SELECT query_name, MAX(snapshot_time) FROM bug_snapshots_hourly WHERE datestamp = $1 AND snapshot_time <= $2 GROUP BY query_name)"
+	if where != expected {
+		t.Errorf("expected %q, got %q", expected, where)
+	}
+	if len(args) != 2 || args[0] != "2018-06-01" {
+		t.Fatalf("unexpected args %v", args)
+	}
+	if at, ok := args[1].(time.Time); !ok || at.UTC().Hour() != 19 {
+		t.Errorf("expected the time of the snapshot, got %v", args[1])
+	}
+}
+
+func TestCountEvents(t *testing.T) {
+	query, args := countEvents(EventDisappeared, "2018-06-01", "2018-06-02", BugFilter{Components: []string{"web"}})
+	if !strings.Contains(query, "bugs.datestamp = bug_events.previous_datestamp") {
+		t.Errorf("expected disappeared bugs to be matched from the previous snapshot: %q", query)
+	}
+	if !strings.Contains(query, "bug_events.datestamp = $2 AND bug_events.previous_datestamp = $3") {
+		t.Errorf("expected only the events between the start and end dates: %q", query)
+	}
+	if !strings.HasSuffix(query, "AND NOT EXISTS (SELECT 1 FROM bugs other WHERE other.datestamp = $5 AND other.source = bugs.source AND other.id = bugs.id)") {
+		t.Errorf("expected bugs still present on the end date to be left out: %q", query)
+	}
+	if len(args) != 5 || args[0] != EventDisappeared || args[1] != "2018-06-02" || args[2] != "2018-06-01" || args[3] != "web" || args[4] != "2018-06-02" {
+		t.Errorf("unexpected args %v", args)
+	}
+}
+
+func TestCountEventsForQuery(t *testing.T) {
+	query, args := countEvents(EventAppeared, "2018-06-01", "2018-06-02", BugFilter{QueryName: "q"})
+	if strings.Contains(query, "NOT EXISTS") || !strings.Contains(query, "bugs.datestamp = bug_events.datestamp") {
+		t.Errorf("unexpected query for a single snapshot query: %q", query)
+	}
+	if !strings.Contains(query, "bug_events.previous_datestamp = $3") || !strings.HasSuffix(query, "AND bugs.query_name = $4") {
+		t.Errorf("expected the query's events relative to the start date: %q", query)
+	}
+	if len(args) != 4 || args[2] != "2018-06-01" || args[3] != "q" {
+		t.Errorf("unexpected args %v", args)
+	}
+}
+
+func TestConsecutiveSnapshots(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2018, 6, d, 0, 0, 0, 0, time.UTC)
+	}
+	daily := querySnapshot{name: "daily", present: true, previous: day(1)}
+
+	tests := []struct {
+		name      string
+		start     string
+		snapshots []querySnapshot
+		expected  bool
+	}{
+		{
+			name:      "consecutive",
+			start:     "2018-06-01",
+			snapshots: []querySnapshot{daily},
+			expected:  true,
+		},
+		{
+			name:      "skipped day",
+			start:     "2018-06-01",
+			snapshots: []querySnapshot{daily, {name: "skipped", present: true, previous: day(0)}},
+		},
+		{
+			name:      "start date further back",
+			start:     "2018-05-25",
+			snapshots: []querySnapshot{daily},
+		},
+		{
+			name:      "query missing on the end date",
+			start:     "2018-06-01",
+			snapshots: []querySnapshot{daily, {name: "removed", previous: day(1)}},
+		},
+		{
+			name:      "first snapshot",
+			start:     "0001-01-01",
+			snapshots: []querySnapshot{{name: "new", present: true}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := consecutiveSnapshots(tt.start, tt.snapshots); got != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestQuerySnapshots(t *testing.T) {
+	// A query that skipped a day only stops the events from being used if it is part of the breakdown
+	query, args := querySnapshots("2018-06-01", "2018-06-02", "")
+	if strings.Contains(query, "WHERE q.query_name") || len(args) != 2 {
+		t.Errorf("expected every query's snapshots: %q %v", query, args)
+	}
+
+	query, args = querySnapshots("2018-06-01", "2018-06-02", "q")
+	if !strings.HasSuffix(query, "WHERE q.query_name = $3") || len(args) != 3 || args[2] != "q" {
+		t.Errorf("expected only the named query's snapshots: %q %v", query, args)
+	}
+}
@@ -0,0 +1,183 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// DefaultURL is the github api used if no url is given
+const DefaultURL = "https://api.github.com"
+
+// pageSize is the number of issues requested at a time, which is the most github allows
+const pageSize = 100
+
+// Client knows how to fetch the issues and pull requests of a repository
+// It represents a client for the github REST API
+type Client interface {
+	Issues(ctx context.Context, repo string, filter IssueFilter) ([]Issue, error)
+	Issue(ctx context.Context, ref Ref) (Issue, error)
+}
+
+// IssueFilter narrows down the issues listed for a repository
+// Empty fields are ignored
+type IssueFilter struct {
+	// State is open (default), closed, or all
+	State string
+	// Labels only keeps issues with all of the given labels
+	Labels []string
+	// Milestone only keeps issues in the milestone with the given title
+	Milestone string
+}
+
+// Options holds the optional settings for a client
+type Options struct {
+	// Token is a personal access token.  Without one, only public repositories can be read.
+	Token string
+	// HTTPClient is used for every request.  Defaults to http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+// httpClient returns the http client to use for the options
+func (o Options) httpClient() *http.Client {
+	if o.HTTPClient == nil {
+		return http.DefaultClient
+	}
+	return o.HTTPClient
+}
+
+// Error is returned by the client when github (or the connection to it) fails
+type Error struct {
+	// StatusCode is the HTTP status of the response.  Zero if no response was received.
+	StatusCode int
+	// Message is the message from github or a description of the failure
+	Message string
+}
+
+func (e *Error) Error() string {
+	if e.StatusCode != 0 {
+		return fmt.Sprintf("github error (status %d): %s", e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("github error: %s", e.Message)
+}
+
+// newError creates an *Error with a formatted message
+func newError(statusCode int, format string, args ...interface{}) *Error {
+	return &Error{StatusCode: statusCode, Message: fmt.Sprintf(format, args...)}
+}
+
+// restGitHubClient is a client for the github REST API
+type restGitHubClient struct {
+	// url is the base of the api, ex) https://api.github.com or https://github.example.com/api/v3
+	url     string
+	options Options
+}
+
+// NewClient creates and returns a client
+// An empty address uses DefaultURL
+func NewClient(address string, options Options) Client {
+	if address == "" {
+		address = DefaultURL
+	}
+	return &restGitHubClient{
+		url:     strings.TrimSuffix(address, "/"),
+		options: options,
+	}
+}
+
+// get sends a GET request to the given path and unmarshals the response
+// Failures reported by github or the connection are returned as an *Error
+func (c *restGitHubClient) get(ctx context.Context, path string, params url.Values, result interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, c.url+path+"?"+params.Encode(), nil)
+	if err != nil {
+		return newError(0, "unable to create http request: %v", err)
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	if c.options.Token != "" {
+		req.Header.Set("Authorization", "token "+c.options.Token)
+	}
+
+	response, err := c.options.httpClient().Do(req)
+	if err != nil {
+		return newError(0, "error when GETting %s: %v", path, err)
+	}
+	defer response.Body.Close()
+
+	byteRes, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return newError(response.StatusCode, "error reading from response body: %v", err)
+	}
+
+	if response.StatusCode != http.StatusOK {
+		// Keep what github said about the failure if we can, ex) a rate limit
+		var body struct {
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(byteRes, &body) == nil && body.Message != "" {
+			return newError(response.StatusCode, "%s", body.Message)
+		}
+		return newError(response.StatusCode, "unexpected response status %q", response.Status)
+	}
+
+	err = json.Unmarshal(byteRes, result)
+	if err != nil {
+		return newError(response.StatusCode, "unable to unmarshal http response: %v", err)
+	}
+	return nil
+}
+
+// Issues returns the issues and pull requests of the repository (owner/name) that match the filter
+// The issues are requested a page at a time until github runs out
+func (c *restGitHubClient) Issues(ctx context.Context, repo string, filter IssueFilter) ([]Issue, error) {
+	state := filter.State
+	if state == "" {
+		state = "open"
+	}
+
+	var all []Issue
+	for page := 1; ; page++ {
+		params := url.Values{}
+		params.Set("state", state)
+		if len(filter.Labels) > 0 {
+			params.Set("labels", strings.Join(filter.Labels, ","))
+		}
+		params.Set("per_page", strconv.Itoa(pageSize))
+		params.Set("page", strconv.Itoa(page))
+
+		var issues []Issue
+		err := c.get(ctx, "/repos/"+repo+"/issues", params, &issues)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, issue := range issues {
+			// The api filters milestones by number, so match the title here
+			if filter.Milestone != "" && issue.Milestone != filter.Milestone {
+				continue
+			}
+			issue.Repo = repo
+			all = append(all, issue)
+		}
+
+		if len(issues) < pageSize {
+			return all, nil
+		}
+	}
+}
+
+// Issue returns a single issue or pull request
+func (c *restGitHubClient) Issue(ctx context.Context, ref Ref) (Issue, error) {
+	var issue Issue
+	err := c.get(ctx, "/repos/"+ref.Repo+"/issues/"+strconv.Itoa(ref.Number), url.Values{}, &issue)
+	if err != nil {
+		return Issue{}, err
+	}
+	issue.Repo = ref.Repo
+	return issue, nil
+}
@@ -0,0 +1,113 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// newTestServer creates a fake github with the given number of issues in org/repo
+// Every other issue is in the 4.1 milestone, and every third issue is a merged pull request
+func newTestServer(t *testing.T, total int, check func(r *http.Request)) *httptest.Server {
+	issue := func(n int) string {
+		milestone := "null"
+		if n%2 == 0 {
+			milestone = `{"title": "4.1"}`
+		}
+		pull := ""
+		if n%3 == 0 {
+			pull = `"pull_request": {"merged_at": "2018-01-02T15:04:05Z"},`
+		}
+		return fmt.Sprintf(`{"number": %d, "title": "issue %d", "state": "open", %s "milestone": %s, "labels": [{"name": "kind/bug"}], "user": {"login": "someone"}}`, n, n, pull, milestone)
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if check != nil {
+			check(r)
+		}
+		if strings.HasPrefix(r.URL.Path, "/repos/org/repo/issues/") {
+			n, _ := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/repos/org/repo/issues/"))
+			if n < 1 || n > total {
+				w.WriteHeader(http.StatusNotFound)
+				fmt.Fprint(w, `{"message": "Not Found"}`)
+				return
+			}
+			fmt.Fprint(w, issue(n))
+			return
+		}
+		if r.URL.Path != "/repos/org/repo/issues" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		var issues []string
+		for n := (page-1)*pageSize + 1; n <= total && n <= page*pageSize; n++ {
+			issues = append(issues, issue(n))
+		}
+		fmt.Fprintf(w, "[%s]", strings.Join(issues, ","))
+	}))
+}
+
+func TestIssues(t *testing.T) {
+	server := newTestServer(t, 150, func(r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "token secret" {
+			t.Errorf("unexpected authorization %q", auth)
+		}
+		if r.URL.Path == "/repos/org/repo/issues" && (r.URL.Query().Get("labels") != "kind/bug,lgtm" || r.URL.Query().Get("state") != "open") {
+			t.Errorf("unexpected query %q", r.URL.RawQuery)
+		}
+	})
+	defer server.Close()
+
+	client := NewClient(server.URL+"/", Options{Token: "secret"})
+	issues, err := client.Issues(context.Background(), "org/repo", IssueFilter{Labels: []string{"kind/bug", "lgtm"}})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(issues) != 150 {
+		t.Fatalf("expected 150 issues, got %d", len(issues))
+	}
+	if issues[0].Repo != "org/repo" || issues[0].Number != 1 || issues[0].Author != "someone" || issues[0].Labels[0] != "kind/bug" {
+		t.Errorf("unexpected issue: %#v", issues[0])
+	}
+
+	issues, err = client.Issues(context.Background(), "org/repo", IssueFilter{Labels: []string{"kind/bug", "lgtm"}, Milestone: "4.1"})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(issues) != 75 {
+		t.Fatalf("expected 75 issues in the milestone, got %d", len(issues))
+	}
+}
+
+func TestIssue(t *testing.T) {
+	server := newTestServer(t, 10, nil)
+	defer server.Close()
+
+	client := NewClient(server.URL, Options{})
+	issue, err := client.Issue(context.Background(), Ref{Repo: "org/repo", Number: 3})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if issue.Repo != "org/repo" || !issue.PullRequest || !issue.Merged() {
+		t.Errorf("expected a merged pull request, got %#v", issue)
+	}
+
+	issue, err = client.Issue(context.Background(), Ref{Repo: "org/repo", Number: 4})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if issue.PullRequest || issue.Merged() || issue.Milestone != "4.1" {
+		t.Errorf("expected an issue in the 4.1 milestone, got %#v", issue)
+	}
+
+	_, err = client.Issue(context.Background(), Ref{Repo: "org/repo", Number: 11})
+	ghErr, ok := err.(*Error)
+	if !ok || ghErr.StatusCode != http.StatusNotFound || ghErr.Message != "Not Found" {
+		t.Errorf("expected a not found *Error, got %#v", err)
+	}
+}
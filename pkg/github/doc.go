@@ -0,0 +1,15 @@
+/*  Package github implements a basic github REST api wrapper for issues and pull requests
+
+Create a new Client with a token and call Issues to list the open issues and
+pull requests of a repository, or Issue to fetch a single one by its Ref.
+The issues api covers pull requests too, so Issue reports whether a pull
+request has been merged without a second request.
+
+ParseRef reads the references that bugzilla stores for github external bugs,
+ex) org/repo/pull/123, so that linked pull requests can be looked up.
+
+Failures are returned as an *Error, which keeps the HTTP status and the
+message that github sent back.
+
+*/
+package github
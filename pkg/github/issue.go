@@ -0,0 +1,137 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// Issue is a github issue or pull request
+type Issue struct {
+	// Repo is the repository the issue is in, ex) org/repo
+	Repo   string
+	Number int
+	Title  string
+	// State is open or closed.  A merged pull request is closed.
+	State       string
+	URL         string
+	PullRequest bool
+	// MergedAt is when the pull request was merged, or the zero time if it wasn't
+	MergedAt  time.Time
+	Labels    []string
+	Milestone string
+	Assignees []string
+	Author    string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	ClosedAt  time.Time
+	// DateStamp is the date that the issue was recorded
+	DateStamp time.Time
+}
+
+// Merged is whether the issue is a pull request that has been merged
+func (i Issue) Merged() bool {
+	return i.PullRequest && !i.MergedAt.IsZero()
+}
+
+// githubUser is a user in the github json
+type githubUser struct {
+	Login string `json:"login"`
+}
+
+// githubIssue is the github json for an issue or pull request
+type githubIssue struct {
+	Number  int    `json:"number"`
+	Title   string `json:"title"`
+	State   string `json:"state"`
+	HTMLURL string `json:"html_url"`
+	// PullRequest is only set for pull requests
+	PullRequest *struct {
+		MergedAt *time.Time `json:"merged_at"`
+	} `json:"pull_request"`
+	Labels []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+	Milestone *struct {
+		Title string `json:"title"`
+	} `json:"milestone"`
+	Assignees []githubUser `json:"assignees"`
+	User      githubUser   `json:"user"`
+	CreatedAt *time.Time   `json:"created_at"`
+	UpdatedAt *time.Time   `json:"updated_at"`
+	ClosedAt  *time.Time   `json:"closed_at"`
+}
+
+// timeOrZero returns the time, or the zero time for null
+func timeOrZero(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return *t
+}
+
+// UnmarshalJSON flattens the github json for an issue or pull request
+func (i *Issue) UnmarshalJSON(data []byte) error {
+	var gi githubIssue
+	err := json.Unmarshal(data, &gi)
+	if err != nil {
+		return err
+	}
+
+	*i = Issue{
+		Number:    gi.Number,
+		Title:     gi.Title,
+		State:     gi.State,
+		URL:       gi.HTMLURL,
+		Author:    gi.User.Login,
+		CreatedAt: timeOrZero(gi.CreatedAt),
+		UpdatedAt: timeOrZero(gi.UpdatedAt),
+		ClosedAt:  timeOrZero(gi.ClosedAt),
+		Labels:    make([]string, len(gi.Labels)),
+		Assignees: make([]string, len(gi.Assignees)),
+	}
+	if gi.PullRequest != nil {
+		i.PullRequest = true
+		i.MergedAt = timeOrZero(gi.PullRequest.MergedAt)
+	}
+	if gi.Milestone != nil {
+		i.Milestone = gi.Milestone.Title
+	}
+	for n, label := range gi.Labels {
+		i.Labels[n] = label.Name
+	}
+	for n, assignee := range gi.Assignees {
+		i.Assignees[n] = assignee.Login
+	}
+	return nil
+}
+
+// Ref points at a single issue or pull request
+type Ref struct {
+	// Repo is the repository, ex) org/repo
+	Repo   string
+	Number int
+}
+
+func (r Ref) String() string {
+	return fmt.Sprintf("%s#%d", r.Repo, r.Number)
+}
+
+// refPattern matches org/repo/pull/123 or org/repo/issues/123, optionally as a github url
+var refPattern = regexp.MustCompile(`^(?:https?://[^/]+/)?([\w.-]+/[\w.-]+)/(?:pull|issues)/(\d+)/?$`)
+
+// ParseRef reads a reference to an issue or pull request, as stored by bugzilla for github external bugs
+// The second return is false if the id isn't a github reference
+func ParseRef(id string) (Ref, bool) {
+	match := refPattern.FindStringSubmatch(id)
+	if match == nil {
+		return Ref{}, false
+	}
+	number, err := strconv.Atoi(match[2])
+	if err != nil {
+		return Ref{}, false
+	}
+	return Ref{Repo: match[1], Number: number}, true
+}
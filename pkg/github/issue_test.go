@@ -0,0 +1,30 @@
+package github
+
+import (
+	"testing"
+)
+
+func TestParseRef(t *testing.T) {
+	tests := []struct {
+		id  string
+		ref Ref
+		ok  bool
+	}{
+		{id: "openshift/origin/pull/12345", ref: Ref{Repo: "openshift/origin", Number: 12345}, ok: true},
+		{id: "openshift/origin/issues/42", ref: Ref{Repo: "openshift/origin", Number: 42}, ok: true},
+		{id: "https://github.com/org/my.repo/pull/7/", ref: Ref{Repo: "org/my.repo", Number: 7}, ok: true},
+		{id: "openshift/origin", ok: false},
+		{id: "openshift/origin/pull/abc", ok: false},
+		{id: "12345", ok: false},
+		{id: "", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			ref, ok := ParseRef(tt.id)
+			if ok != tt.ok || ref != tt.ref {
+				t.Errorf("expected %v %v, got %v %v", tt.ref, tt.ok, ref, ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// DefaultPageSize is the number of issues requested per page if no page size is given
+// Jira caps the page size (usually at 100) and returns fewer issues if it is too large
+const DefaultPageSize = 100
+
+// Client knows how to run a JQL search
+// It represents a client for the jira REST API
+type Client interface {
+	Search(jql string, fields []string) ([]Issue, error)
+	SearchContext(ctx context.Context, jql string, fields []string) ([]Issue, error)
+}
+
+// Credentials are used to log in to jira
+// A token with a username uses basic auth (jira cloud api tokens), while a token
+// without a username is sent as a bearer token (jira server personal access tokens).
+type Credentials struct {
+	Username string
+	Password string
+	Token    string
+}
+
+// setHeaders adds the credentials to the request
+func (c Credentials) setHeaders(req *http.Request) {
+	switch {
+	case c.Token != "" && c.Username == "":
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	case c.Token != "":
+		req.SetBasicAuth(c.Username, c.Token)
+	case c.Username != "":
+		req.SetBasicAuth(c.Username, c.Password)
+	}
+}
+
+// Options holds the optional settings for a client
+type Options struct {
+	// PageSize is the number of issues requested at a time.  Defaults to DefaultPageSize.
+	PageSize int
+	// HTTPClient is used for every request.  Defaults to http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+// pageSize returns the page size to use for the options
+func (o Options) pageSize() int {
+	if o.PageSize <= 0 {
+		return DefaultPageSize
+	}
+	return o.PageSize
+}
+
+// httpClient returns the http client to use for the options
+func (o Options) httpClient() *http.Client {
+	if o.HTTPClient == nil {
+		return http.DefaultClient
+	}
+	return o.HTTPClient
+}
+
+// Error is returned by the client when jira (or the connection to it) fails
+type Error struct {
+	// StatusCode is the HTTP status of the response.  Zero if no response was received.
+	StatusCode int
+	// Messages are the error messages from jira or a description of the failure
+	Messages []string
+}
+
+func (e *Error) Error() string {
+	if e.StatusCode != 0 {
+		return fmt.Sprintf("jira error (status %d): %s", e.StatusCode, strings.Join(e.Messages, "; "))
+	}
+	return fmt.Sprintf("jira error: %s", strings.Join(e.Messages, "; "))
+}
+
+// newError creates an *Error with a formatted message
+func newError(statusCode int, format string, args ...interface{}) *Error {
+	return &Error{StatusCode: statusCode, Messages: []string{fmt.Sprintf(format, args...)}}
+}
+
+// errorBody is the body of a failed request
+type errorBody struct {
+	ErrorMessages []string          `json:"errorMessages"`
+	Errors        map[string]string `json:"errors"`
+}
+
+// messages returns every message in the body, field errors included
+func (b errorBody) messages() []string {
+	messages := append([]string{}, b.ErrorMessages...)
+	for field, message := range b.Errors {
+		messages = append(messages, field+": "+message)
+	}
+	return messages
+}
+
+// searchResult is a single page of a search
+type searchResult struct {
+	StartAt    int     `json:"startAt"`
+	MaxResults int     `json:"maxResults"`
+	Total      int     `json:"total"`
+	Issues     []Issue `json:"issues"`
+}
+
+// restJiraClient is a client for the jira REST API
+type restJiraClient struct {
+	creds Credentials
+	// url is the base of the jira instance, ex) https://jira.example.com
+	url     string
+	options Options
+}
+
+// NewClient creates and returns a client
+// The address is the base of the jira instance rather than a specific endpoint
+func NewClient(creds Credentials, address string, options Options) Client {
+	return &restJiraClient{
+		creds:   creds,
+		url:     strings.TrimSuffix(address, "/"),
+		options: options,
+	}
+}
+
+// get sends a GET request to the given path and unmarshals the response
+// Failures reported by jira or the connection are returned as an *Error
+func (c *restJiraClient) get(ctx context.Context, path string, params url.Values, result interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, c.url+path+"?"+params.Encode(), nil)
+	if err != nil {
+		return newError(0, "unable to create http request: %v", err)
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Accept", "application/json")
+	c.creds.setHeaders(req)
+
+	response, err := c.options.httpClient().Do(req)
+	if err != nil {
+		return newError(0, "error when GETting %s: %v", path, err)
+	}
+	defer response.Body.Close()
+
+	byteRes, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return newError(response.StatusCode, "error reading from response body: %v", err)
+	}
+
+	if response.StatusCode != http.StatusOK {
+		// Keep what jira said about the failure if we can
+		var body errorBody
+		if json.Unmarshal(byteRes, &body) == nil && len(body.messages()) > 0 {
+			return &Error{StatusCode: response.StatusCode, Messages: body.messages()}
+		}
+		return newError(response.StatusCode, "unexpected response status %q", response.Status)
+	}
+
+	err = json.Unmarshal(byteRes, result)
+	if err != nil {
+		return newError(response.StatusCode, "unable to unmarshal http response: %v", err)
+	}
+	return nil
+}
+
+// Search returns all issues that match the JQL query
+func (c *restJiraClient) Search(jql string, fields []string) ([]Issue, error) {
+	return c.SearchContext(context.Background(), jql, fields)
+}
+
+// SearchContext returns all issues that match the JQL query using GET /rest/api/2/search
+// The issues are requested a page at a time until the total is reached or a page is empty
+// Failures reported by jira or the connection are returned as an *Error
+func (c *restJiraClient) SearchContext(ctx context.Context, jql string, fields []string) ([]Issue, error) {
+	var all []Issue
+	for {
+		params := url.Values{}
+		params.Set("jql", jql)
+		params.Set("startAt", strconv.Itoa(len(all)))
+		params.Set("maxResults", strconv.Itoa(c.options.pageSize()))
+		if len(fields) > 0 {
+			params.Set("fields", strings.Join(fields, ","))
+		}
+
+		var page searchResult
+		err := c.get(ctx, "/rest/api/2/search", params, &page)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, page.Issues...)
+
+		if len(page.Issues) == 0 || len(all) >= page.Total {
+			return all, nil
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package jira
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+// newTestServer creates a fake jira search api with the given number of issues
+// Pages are capped at two issues, like a server with a low maxResults limit
+func newTestServer(t *testing.T, total int, check func(r *http.Request)) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/2/search" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		if check != nil {
+			check(r)
+		}
+		startAt, _ := strconv.Atoi(r.URL.Query().Get("startAt"))
+
+		issues := ""
+		for i := startAt; i < total && i < startAt+2; i++ {
+			if issues != "" {
+				issues += ","
+			}
+			issues += fmt.Sprintf(`{"id": "%d", "key": "PROJ-%d", "fields": {"summary": "issue %d"}}`, 1000+i, i, i)
+		}
+		fmt.Fprintf(w, `{"startAt": %d, "maxResults": 2, "total": %d, "issues": [%s]}`, startAt, total, issues)
+	}))
+}
+
+func TestSearch(t *testing.T) {
+	server := newTestServer(t, 5, func(r *http.Request) {
+		if jql := r.URL.Query().Get("jql"); jql != "project = PROJ" {
+			t.Errorf("unexpected jql %q", jql)
+		}
+		if fields := r.URL.Query().Get("fields"); fields != "summary,status" {
+			t.Errorf("unexpected fields %q", fields)
+		}
+	})
+	defer server.Close()
+
+	issues, err := NewClient(Credentials{}, server.URL+"/", Options{PageSize: 50}).Search("project = PROJ", []string{"summary", "status"})
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	if len(issues) != 5 {
+		t.Fatalf("expected 5 issues, got %d", len(issues))
+	}
+	for i, issue := range issues {
+		if issue.Key != fmt.Sprintf("PROJ-%d", i) {
+			t.Errorf("expected issue %d to be PROJ-%d, got %q", i, i, issue.Key)
+		}
+	}
+}
+
+func TestSearchAuth(t *testing.T) {
+	tests := []struct {
+		name  string
+		creds Credentials
+		check func(r *http.Request) bool
+	}{
+		{
+			name:  "api token",
+			creds: Credentials{Username: "user@example.com", Token: "token"},
+			check: func(r *http.Request) bool {
+				user, pass, ok := r.BasicAuth()
+				return ok && user == "user@example.com" && pass == "token"
+			},
+		},
+		{
+			name:  "personal access token",
+			creds: Credentials{Token: "token"},
+			check: func(r *http.Request) bool {
+				return r.Header.Get("Authorization") == "Bearer token"
+			},
+		},
+		{
+			name:  "password",
+			creds: Credentials{Username: "user", Password: "pass"},
+			check: func(r *http.Request) bool {
+				user, pass, ok := r.BasicAuth()
+				return ok && user == "user" && pass == "pass"
+			},
+		},
+		{
+			name:  "anonymous",
+			creds: Credentials{},
+			check: func(r *http.Request) bool {
+				return r.Header.Get("Authorization") == ""
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newTestServer(t, 1, func(r *http.Request) {
+				if !tt.check(r) {
+					t.Errorf("unexpected authorization %q", r.Header.Get("Authorization"))
+				}
+			})
+			defer server.Close()
+
+			_, err := NewClient(tt.creds, server.URL, Options{}).Search("project = PROJ", nil)
+			if err != nil {
+				t.Fatalf("unexpected err: %v", err)
+			}
+		})
+	}
+}
+
+func TestSearchErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		messages []string
+	}{
+		{
+			name:     "bad jql",
+			status:   http.StatusBadRequest,
+			body:     `{"errorMessages": ["Field 'foo' does not exist."], "errors": {}}`,
+			messages: []string{"Field 'foo' does not exist."},
+		},
+		{
+			name:     "field error",
+			status:   http.StatusBadRequest,
+			body:     `{"errorMessages": [], "errors": {"jql": "invalid"}}`,
+			messages: []string{"jql: invalid"},
+		},
+		{
+			name:     "unauthorized",
+			status:   http.StatusUnauthorized,
+			body:     `<html>Unauthorized</html>`,
+			messages: []string{`unexpected response status "401 Unauthorized"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				fmt.Fprint(w, tt.body)
+			}))
+			defer server.Close()
+
+			_, err := NewClient(Credentials{}, server.URL, Options{}).Search("foo = bar", nil)
+			jiraErr, ok := err.(*Error)
+			if !ok {
+				t.Fatalf("expected a *Error, got %#v", err)
+			}
+			if jiraErr.StatusCode != tt.status {
+				t.Errorf("expected status %d, got %d", tt.status, jiraErr.StatusCode)
+			}
+			if fmt.Sprint(jiraErr.Messages) != fmt.Sprint(tt.messages) {
+				t.Errorf("expected messages %q, got %q", tt.messages, jiraErr.Messages)
+			}
+		})
+	}
+}
+
+func TestSearchUnreachable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+
+	_, err := NewClient(Credentials{}, server.URL, Options{}).Search("project = PROJ", nil)
+	jiraErr, ok := err.(*Error)
+	if !ok || jiraErr.StatusCode != 0 {
+		t.Fatalf("expected a *Error without a status, got %#v", err)
+	}
+}
@@ -0,0 +1,15 @@
+/*  Package jira implements a basic jira REST api wrapper with a single JQL search
+
+Create a new Client with the required credentials and call Search with a JQL
+query.  Issues are requested a page at a time (see Options) from
+/rest/api/2/search until jira runs out of issues.
+
+Issues can be converted into bugzilla.Bugs with Bugs so that they are stored
+in the same snapshot rows as bugzilla bugs.  The bugs keep the jira source,
+and their raw json holds the issue's fields along with its id and key.
+
+Failures are returned as an *Error, which keeps the HTTP status and any
+messages that jira sent back.
+
+*/
+package jira
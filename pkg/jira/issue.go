@@ -0,0 +1,220 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/thrasher-redhat/internal-tools/pkg/bugzilla"
+)
+
+// Source is the name stored with bugs that came from jira
+const Source = "jira"
+
+// DefaultFields are the issue fields needed to fill in a bug
+var DefaultFields = []string{
+	"summary",
+	"status",
+	"components",
+	"fixVersions",
+	"assignee",
+	"reporter",
+	"priority",
+	"labels",
+	"resolution",
+	"created",
+	"updated",
+	"issuelinks",
+}
+
+// timeFormat is how jira formats times, ex) 2018-01-02T15:04:05.000+0000
+const timeFormat = "2006-01-02T15:04:05.000-0700"
+
+// blocksLink is the name of the link type used for dependencies
+const blocksLink = "Blocks"
+
+// Issue is a single jira issue from a search
+// The fields are kept as json, as the fields returned depend on the search
+type Issue struct {
+	ID     string          `json:"id"`
+	Key    string          `json:"key"`
+	Fields json.RawMessage `json:"fields"`
+}
+
+// named is any field that jira represents as an object with a name
+type named struct {
+	Name string `json:"name"`
+}
+
+// user is a jira user.  Jira cloud hides the name and jira server may hide the email.
+type user struct {
+	Name         string `json:"name"`
+	EmailAddress string `json:"emailAddress"`
+}
+
+// login returns the best identifier for the user, or an empty string for no user
+func (u *user) login() string {
+	if u == nil {
+		return ""
+	}
+	if u.EmailAddress != "" {
+		return u.EmailAddress
+	}
+	return u.Name
+}
+
+// linkedIssue is the other side of an issue link
+type linkedIssue struct {
+	ID string `json:"id"`
+}
+
+// issueLink is a link between two issues.  Only one of the issues is set.
+type issueLink struct {
+	Type         named        `json:"type"`
+	InwardIssue  *linkedIssue `json:"inwardIssue"`
+	OutwardIssue *linkedIssue `json:"outwardIssue"`
+}
+
+// issueFields are the fields used to fill in a bug
+type issueFields struct {
+	Summary     string      `json:"summary"`
+	Status      *named      `json:"status"`
+	Components  []named     `json:"components"`
+	FixVersions []named     `json:"fixVersions"`
+	Assignee    *user       `json:"assignee"`
+	Reporter    *user       `json:"reporter"`
+	Priority    *named      `json:"priority"`
+	Labels      []string    `json:"labels"`
+	Resolution  *named      `json:"resolution"`
+	Created     string      `json:"created"`
+	Updated     string      `json:"updated"`
+	IssueLinks  []issueLink `json:"issuelinks"`
+}
+
+// name returns the name of an optional field
+func name(n *named) string {
+	if n == nil {
+		return ""
+	}
+	return n.Name
+}
+
+// names returns the names of a list field
+func names(ns []named) []string {
+	list := make([]string, len(ns))
+	for i, n := range ns {
+		list[i] = n.Name
+	}
+	return list
+}
+
+// parseTime parses a jira time.  An empty time is the zero time.
+func parseTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(timeFormat, value)
+	if err != nil {
+		// Some instances use RFC3339 instead
+		t, err = time.Parse(time.RFC3339, value)
+	}
+	return t, err
+}
+
+// raw returns the issue's fields with its id and key, so they can be looked up like bugzilla fields
+func (i Issue) raw() (json.RawMessage, error) {
+	fields := make(map[string]json.RawMessage)
+	if len(i.Fields) > 0 {
+		err := json.Unmarshal(i.Fields, &fields)
+		if err != nil {
+			return nil, err
+		}
+	}
+	fields["id"], _ = json.Marshal(i.ID)
+	fields["key"], _ = json.Marshal(i.Key)
+	return json.Marshal(fields)
+}
+
+// Bug converts the issue into a bug so that it can be stored with the bugzilla bugs
+// Components and fix versions become the bug's components and target releases, and labels become keywords.
+// Issues linked with the Blocks link type fill in the dependencies.
+func (i Issue) Bug() (bugzilla.Bug, error) {
+	id, err := strconv.Atoi(i.ID)
+	if err != nil {
+		return bugzilla.Bug{}, fmt.Errorf("issue %s has an invalid id %q: %v", i.Key, i.ID, err)
+	}
+
+	var fields issueFields
+	if len(i.Fields) > 0 {
+		err = json.Unmarshal(i.Fields, &fields)
+		if err != nil {
+			return bugzilla.Bug{}, fmt.Errorf("unable to unmarshal fields of issue %s: %v", i.Key, err)
+		}
+	}
+
+	raw, err := i.raw()
+	if err != nil {
+		return bugzilla.Bug{}, fmt.Errorf("unable to marshal fields of issue %s: %v", i.Key, err)
+	}
+
+	created, err := parseTime(fields.Created)
+	if err != nil {
+		return bugzilla.Bug{}, fmt.Errorf("issue %s has an invalid created time: %v", i.Key, err)
+	}
+	updated, err := parseTime(fields.Updated)
+	if err != nil {
+		return bugzilla.Bug{}, fmt.Errorf("issue %s has an invalid updated time: %v", i.Key, err)
+	}
+
+	bug := bugzilla.Bug{
+		ID:             id,
+		Source:         Source,
+		Component:      names(fields.Components),
+		TargetRelease:  names(fields.FixVersions),
+		AssignedTo:     fields.Assignee.login(),
+		Status:         name(fields.Status),
+		Keywords:       fields.Labels,
+		Summary:        fields.Summary,
+		Priority:       name(fields.Priority),
+		Reporter:       fields.Reporter.login(),
+		CreationTime:   created,
+		LastChangeTime: updated,
+		Resolution:     name(fields.Resolution),
+		Raw:            raw,
+	}
+
+	for _, link := range fields.IssueLinks {
+		if link.Type.Name != blocksLink {
+			continue
+		}
+		// The outward issue is blocked by this one, and the inward issue blocks this one
+		if link.OutwardIssue != nil {
+			blocked, err := strconv.Atoi(link.OutwardIssue.ID)
+			if err == nil {
+				bug.Blocks = append(bug.Blocks, blocked)
+			}
+		}
+		if link.InwardIssue != nil {
+			blocker, err := strconv.Atoi(link.InwardIssue.ID)
+			if err == nil {
+				bug.DependsOn = append(bug.DependsOn, blocker)
+			}
+		}
+	}
+
+	return bug, nil
+}
+
+// Bugs converts the issues into bugs
+func Bugs(issues []Issue) (bugzilla.Bugs, error) {
+	bugs := bugzilla.Bugs{Bugs: make([]bugzilla.Bug, 0, len(issues))}
+	for _, i := range issues {
+		bug, err := i.Bug()
+		if err != nil {
+			return bugzilla.Bugs{}, err
+		}
+		bugs.Bugs = append(bugs.Bugs, bug)
+	}
+	return bugs, nil
+}
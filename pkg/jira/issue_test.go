@@ -0,0 +1,90 @@
+package jira
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestIssueBug(t *testing.T) {
+	var issue Issue
+	err := json.Unmarshal([]byte(`{"id": "10001", "key": "PROJ-1", "fields": {
+		"summary": "Something broke",
+		"status": {"name": "In Progress"},
+		"components": [{"name": "api"}, {"name": "ui"}],
+		"fixVersions": [{"name": "1.2.0"}],
+		"assignee": {"name": "jdoe", "emailAddress": "jdoe@example.com"},
+		"reporter": {"name": "asmith"},
+		"priority": {"name": "Major"},
+		"labels": ["regression"],
+		"resolution": null,
+		"created": "2018-01-02T15:04:05.000+0000",
+		"updated": "2018-01-03T10:00:00.000-0500",
+		"issuelinks": [
+			{"type": {"name": "Blocks"}, "outwardIssue": {"id": "10002", "key": "PROJ-2"}},
+			{"type": {"name": "Blocks"}, "inwardIssue": {"id": "10003", "key": "PROJ-3"}},
+			{"type": {"name": "Relates"}, "outwardIssue": {"id": "10004", "key": "PROJ-4"}}
+		],
+		"customfield_10002": 5
+	}}`), &issue)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	bug, err := issue.Bug()
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+
+	if bug.ID != 10001 || bug.Source != Source || bug.Summary != "Something broke" || bug.Status != "In Progress" {
+		t.Errorf("unexpected bug: %#v", bug)
+	}
+	if !reflect.DeepEqual([]string(bug.Component), []string{"api", "ui"}) || !reflect.DeepEqual([]string(bug.TargetRelease), []string{"1.2.0"}) {
+		t.Errorf("unexpected components %v or target releases %v", bug.Component, bug.TargetRelease)
+	}
+	if bug.AssignedTo != "jdoe@example.com" || bug.Reporter != "asmith" {
+		t.Errorf("unexpected assignee %q or reporter %q", bug.AssignedTo, bug.Reporter)
+	}
+	if bug.Priority != "Major" || bug.Resolution != "" || !reflect.DeepEqual([]string(bug.Keywords), []string{"regression"}) {
+		t.Errorf("unexpected priority %q, resolution %q or keywords %v", bug.Priority, bug.Resolution, bug.Keywords)
+	}
+	if !bug.CreationTime.Equal(time.Date(2018, 1, 2, 15, 4, 5, 0, time.UTC)) || !bug.LastChangeTime.Equal(time.Date(2018, 1, 3, 15, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected times %v and %v", bug.CreationTime, bug.LastChangeTime)
+	}
+	if !reflect.DeepEqual([]int(bug.Blocks), []int{10002}) || !reflect.DeepEqual([]int(bug.DependsOn), []int{10003}) {
+		t.Errorf("unexpected blocks %v or depends on %v", bug.Blocks, bug.DependsOn)
+	}
+
+	for name, expected := range map[string]string{"key": `"PROJ-1"`, "id": `"10001"`, "customfield_10002": `5`} {
+		value, ok := bug.Field(name)
+		if !ok || string(value) != expected {
+			t.Errorf("expected raw field %q to be %s, got %s", name, expected, value)
+		}
+	}
+}
+
+func TestIssueBugErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		issue Issue
+	}{
+		{
+			name:  "invalid id",
+			issue: Issue{ID: "abc", Key: "PROJ-1"},
+		},
+		{
+			name:  "invalid time",
+			issue: Issue{ID: "1", Key: "PROJ-1", Fields: json.RawMessage(`{"created": "yesterday"}`)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.issue.Bug()
+			if err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
@@ -30,6 +30,8 @@ type Release struct {
 type Configs struct {
 	Releases []Release `yaml:"Releases"`
 	Blockers []string  `yaml:"blockers"`
+	// Trackers maps the meaning of an external tracker (customer_portal, github, jira, errata...) to its bugzilla ids
+	Trackers map[string][]int `yaml:"trackers"`
 }
 
 // PopulateConfigs reads the given yaml file and populates the configuration options structs
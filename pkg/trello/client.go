@@ -3,8 +3,8 @@ package trello
 
 import (
 	"fmt"
-	"log"
 	"strings"
+	"time"
 
 	"github.com/adlio/trello"
 )
@@ -14,8 +14,87 @@ type TrelloCredentials struct {
 	Token string `yaml:"token"`
 }
 
+// Card maps to the desired fields of a trello card
 type Card struct {
+	ID   string
 	Name string
+	// List is the name of the list the card is in
+	List string
+	URL  string
+	// Labels are the names of the card's labels, or the colors of unnamed labels
+	Labels []string
+	// Members are the usernames of the card's members
+	Members []string
+	// Due and LastActivity are zero if unknown
+	Due          time.Time
+	LastActivity time.Time
+	// Closed is whether the card has been archived
+	Closed bool
+	// Description and Attachments (urls) are used to find linked bugs, but aren't stored
+	Description string
+	Attachments []string
+	// Bugs are the ids of the bugzilla bugs that the card references
+	Bugs []int
+	// DateStamp is the date the card was snapshotted
+	DateStamp time.Time
+}
+
+// ListError is a list whose cards could not be loaded
+type ListError struct {
+	List string
+	Err  error
+}
+
+// ListErrors collects the lists whose cards could not be loaded
+type ListErrors []ListError
+
+func (e ListErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, le := range e {
+		msgs[i] = fmt.Sprintf("list %q: %v", le.List, le.Err)
+	}
+	return fmt.Sprintf("unable to get cards for %d lists: %s", len(e), strings.Join(msgs, "; "))
+}
+
+// cardArguments asks for the member usernames and attachment urls along with the cards
+var cardArguments = trello.Arguments{
+	"members":           "true",
+	"member_fields":     "username",
+	"attachments":       "true",
+	"attachment_fields": "url",
+}
+
+// newCard converts a trello card in the given list
+func newCard(card *trello.Card, list string) Card {
+	c := Card{
+		ID:          card.ID,
+		Name:        card.Name,
+		List:        list,
+		URL:         card.URL,
+		Closed:      card.Closed,
+		Description: card.Desc,
+	}
+	for _, label := range card.Labels {
+		name := label.Name
+		if name == "" {
+			name = label.Color
+		}
+		c.Labels = append(c.Labels, name)
+	}
+	for _, member := range card.Members {
+		c.Members = append(c.Members, member.Username)
+	}
+	if card.Due != nil {
+		c.Due = *card.Due
+	}
+	if card.DateLastActivity != nil {
+		c.LastActivity = *card.DateLastActivity
+	}
+	for _, attachment := range card.Attachments {
+		c.Attachments = append(c.Attachments, attachment.URL)
+	}
+	c.Bugs = LinkedBugs(c)
+	return c
 }
 
 // Client does the thing
@@ -29,27 +108,33 @@ type client struct {
 	client *trello.Client
 }
 
-func (c *client) GetAllCards(boardName string) ([]Card, error) {
-	board, err := c.client.GetBoard(boardName, trello.Defaults())
+// GetAllCards fetches all the cards for the board with the given id
+// If the cards of some lists can't be loaded, the rest of the cards are returned with a ListErrors
+func (c *client) GetAllCards(boardID string) ([]Card, error) {
+	board, err := c.client.GetBoard(boardID, trello.Defaults())
 	if err != nil {
-		return nil, fmt.Errorf("no boardsssssss: %v", err)
+		return nil, fmt.Errorf("unable to get board %q: %v", boardID, err)
 	}
 	lists, err := board.GetLists(trello.Defaults())
 	if err != nil {
-		return nil, fmt.Errorf("no listsssssssss: %v", err)
+		return nil, fmt.Errorf("unable to get lists for board %q: %v", boardID, err)
 	}
 
 	var allCards []Card
+	var listErrs ListErrors
 	for _, list := range lists {
-		cards, err := list.GetCards(trello.Defaults())
+		cards, err := list.GetCards(cardArguments)
 		if err != nil {
-			log.Printf("Cannot get cards: %v", err)
+			listErrs = append(listErrs, ListError{List: list.Name, Err: err})
 			continue
 		}
 		for _, card := range cards {
-			allCards = append(allCards, Card{Name: card.Name})
+			allCards = append(allCards, newCard(card, list.Name))
 		}
 	}
+	if len(listErrs) > 0 {
+		return allCards, listErrs
+	}
 	return allCards, nil
 }
 
@@ -61,11 +146,11 @@ func ClientForConfig(creds *TrelloCredentials) Client {
 	}
 }
 
-func FilterCards(tag string, cl Client) ([]Card, error) {
-	cards, err := cl.GetAllCards("myboard")
-	if err != nil {
-		return nil, err
-	}
+// FilterCards fetches the cards for the given board whose names start with the tag
+// An empty tag keeps every card
+// Any error from the client is returned, along with whatever cards were loaded
+func FilterCards(board, tag string, cl Client) ([]Card, error) {
+	cards, err := cl.GetAllCards(board)
 	var res []Card
 	for _, card := range cards {
 		if !strings.HasPrefix(card.Name, tag) {
@@ -74,5 +159,5 @@ func FilterCards(tag string, cl Client) ([]Card, error) {
 		res = append(res, card)
 	}
 
-	return res, nil
+	return res, err
 }
@@ -8,6 +8,8 @@ import (
 type fakeClient struct {
 	// cardList maps board names to card lists
 	cardList map[string][]Card
+	// listErrs maps board names to the lists that failed to load
+	listErrs map[string]ListErrors
 }
 
 func (c *fakeClient) GetAllCards(board string) ([]Card, error) {
@@ -15,6 +17,9 @@ func (c *fakeClient) GetAllCards(board string) ([]Card, error) {
 	if !ok {
 		return nil, fmt.Errorf("No board found %q", board)
 	}
+	if errs, ok := c.listErrs[board]; ok {
+		return cards, errs
+	}
 	return cards, nil
 }
 
@@ -30,7 +35,7 @@ func TestFilterTags(t *testing.T) {
 		},
 	}
 
-	filteredCards, err := FilterCards("foobar", cl)
+	filteredCards, err := FilterCards("myboard", "foobar", cl)
 	if err != nil {
 		t.Fatalf("unexpected err: %v", err)
 	}
@@ -39,3 +44,27 @@ func TestFilterTags(t *testing.T) {
 		t.Fatalf("expected 2 cards, got %#v", filteredCards)
 	}
 }
+
+func TestFilterTagsListErrors(t *testing.T) {
+	cl := &fakeClient{
+		cardList: map[string][]Card{
+			"myboard": {
+				{Name: "foobar 1", List: "Done"},
+				{Name: "bazquux 1", List: "Done"},
+			},
+		},
+		listErrs: map[string]ListErrors{
+			"myboard": {{List: "In Progress", Err: fmt.Errorf("timeout")}},
+		},
+	}
+
+	filteredCards, err := FilterCards("myboard", "foobar", cl)
+	listErrs, ok := err.(ListErrors)
+	if !ok || len(listErrs) != 1 || listErrs[0].List != "In Progress" {
+		t.Fatalf("expected the list errors, got %#v", err)
+	}
+
+	if len(filteredCards) != 1 {
+		t.Fatalf("expected the cards that loaded, got %#v", filteredCards)
+	}
+}
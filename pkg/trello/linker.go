@@ -0,0 +1,44 @@
+package trello
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// bugPatterns find bugzilla ids in free text
+// Ex) BZ#1234567, bz 1234567, rhbz#1234567, Bug 1234567, show_bug.cgi?id=1234567, bugzilla.redhat.com/1234567
+var bugPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)\b(?:rh)?(?:bz|bug)\s*#?\s*(\d{4,8})\b`),
+	regexp.MustCompile(`(?i)show_bug\.cgi\?(?:\S*&)?id=(\d+)`),
+	regexp.MustCompile(`(?i)bugzilla\.[\w.-]+/(\d+)\b`),
+}
+
+// findBugs adds the ids of the bugs referenced in the text to ids
+func findBugs(text string, ids map[int]bool) {
+	for _, pattern := range bugPatterns {
+		for _, match := range pattern.FindAllStringSubmatch(text, -1) {
+			id, err := strconv.Atoi(match[1])
+			if err == nil && id > 0 {
+				ids[id] = true
+			}
+		}
+	}
+}
+
+// LinkedBugs returns the sorted ids of the bugs referenced by the card's name, description, and attachments
+func LinkedBugs(card Card) []int {
+	ids := make(map[int]bool)
+	findBugs(card.Name, ids)
+	findBugs(card.Description, ids)
+	for _, attachment := range card.Attachments {
+		findBugs(attachment, ids)
+	}
+
+	linked := make([]int, 0, len(ids))
+	for id := range ids {
+		linked = append(linked, id)
+	}
+	sort.Ints(linked)
+	return linked
+}
@@ -0,0 +1,50 @@
+package trello
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLinkedBugs(t *testing.T) {
+	tests := []struct {
+		name     string
+		card     Card
+		expected []int
+	}{
+		{
+			name:     "name",
+			card:     Card{Name: "BZ#1234567 fix the thing"},
+			expected: []int{1234567},
+		},
+		{
+			name:     "description",
+			card:     Card{Name: "Fix the thing", Description: "See bug 1234567 and rhbz#7654321"},
+			expected: []int{1234567, 7654321},
+		},
+		{
+			name: "attachments",
+			card: Card{Name: "Fix the thing", Attachments: []string{
+				"https://bugzilla.redhat.com/show_bug.cgi?id=1234567",
+				"https://bugzilla.redhat.com/2345678",
+			}},
+			expected: []int{1234567, 2345678},
+		},
+		{
+			name:     "duplicates",
+			card:     Card{Name: "BZ 1234567", Description: "https://bugzilla.redhat.com/show_bug.cgi?id=1234567"},
+			expected: []int{1234567},
+		},
+		{
+			name:     "no bugs",
+			card:     Card{Name: "Sprint 12 retro", Description: "bugs: none, see https://github.com/org/repo/issues/1234567"},
+			expected: []int{},
+		},
+	}
+
+	for _, test := range tests {
+		linked := LinkedBugs(test.card)
+		if !reflect.DeepEqual(linked, test.expected) {
+			t.Errorf("%s: expected %v, got %v", test.name, test.expected, linked)
+		}
+	}
+}